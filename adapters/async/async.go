@@ -0,0 +1,337 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+// DroppedField is the field name used to print the number of discarded messages on the drop report.
+var DroppedField = "dropped"
+
+// Target is the interface that the wrapped adapter should implement. It is the same as golog.Adapter.
+type Target interface {
+	Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+	Writer() io.Writer
+	SetWriter(w io.Writer)
+	Level() levels.Level
+	SetLevel(level levels.Level)
+}
+
+type exitFuncSetter interface {
+	SetExitFunc(exitFunc func(code int))
+}
+
+type timedTarget interface {
+	LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+type emitter interface {
+	Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+type entry struct {
+	time   time.Time
+	level  levels.Level
+	err    error
+	fields *fields.Fields
+	msg    string
+}
+
+// Adapter is an adapter implementation that writes the log messages on a background goroutine, so slow writers do
+// not block the caller. Messages are kept on a bounded buffer until they are written by the wrapped adapter.
+//
+// Fatal and Panic messages are written on the caller goroutine after flushing the buffer, so they keep the
+// behaviour of the wrapped adapter.
+type Adapter struct {
+	target    Target
+	policy    OverflowPolicy
+	dropLevel levels.Level
+	queue     chan entry
+	mutex     *sync.RWMutex
+	closed    bool
+	done      chan struct{}
+	dropped   atomic.Uint64
+	reported  atomic.Uint64
+	accepted  atomic.Uint64
+	progressM *sync.Mutex
+	progress  chan struct{}
+	processed uint64
+}
+
+// New creates a new async adapter that writes the log messages with the provided adapter.
+func New(target Target, opts ...Option) *Adapter {
+	logOpts := options{
+		bufferSize:     1024,
+		policy:         Block,
+		dropLevel:      levels.Error,
+		reportInterval: 10 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(&logOpts)
+	}
+
+	adapter := &Adapter{
+		target:    target,
+		policy:    logOpts.policy,
+		dropLevel: logOpts.dropLevel,
+		queue:     make(chan entry, logOpts.bufferSize),
+		mutex:     &sync.RWMutex{},
+		done:      make(chan struct{}),
+		progressM: &sync.Mutex{},
+	}
+
+	go adapter.run(logOpts.reportInterval)
+
+	return adapter
+}
+
+// Writer returns the writer of the wrapped adapter.
+func (a *Adapter) Writer() io.Writer {
+	return a.target.Writer()
+}
+
+// SetWriter sets the writer of the wrapped adapter. The buffered messages are flushed first, so the messages logged
+// before the call are written on the previous writer.
+func (a *Adapter) SetWriter(w io.Writer) {
+	_ = a.Flush(context.Background())
+	a.target.SetWriter(w)
+}
+
+// Level returns the level of the wrapped adapter.
+func (a *Adapter) Level() levels.Level {
+	return a.target.Level()
+}
+
+// SetLevel sets the level of the wrapped adapter. The buffered messages are flushed first, so the messages logged
+// before the call are not discarded by the new level.
+func (a *Adapter) SetLevel(level levels.Level) {
+	_ = a.Flush(context.Background())
+	a.target.SetLevel(level)
+}
+
+// SetExitFunc sets the exit function of the wrapped adapter, when it exits the program after writing Fatal messages.
+func (a *Adapter) SetExitFunc(exitFunc func(code int)) {
+	if setter, ok := a.target.(exitFuncSetter); ok {
+		setter.SetExitFunc(exitFunc)
+	}
+}
+
+// Dropped returns the total number of messages discarded because the buffer was full.
+func (a *Adapter) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Log adds the message to the buffer to be written by the wrapped adapter. The message is formatted and the fields
+// are copied before returning, so they can be safely reused by the caller.
+func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt adds the message with the given timestamp to the buffer to be written by the wrapped adapter. If the
+// wrapped adapter can't log with a given timestamp, the message is written with the time it leaves the buffer.
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.log(t, level, err, logFields, msg, args, true)
+}
+
+// Emit adds the message with the given timestamp to the buffer, as LogAt does. Fatal and Panic messages are written
+// with the Emit method of the wrapped adapter when it has one, so it does not exit the program or panic after
+// writing them and the caller can terminate on its own.
+func (a *Adapter) Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.log(t, level, err, logFields, msg, args, false)
+}
+
+func (a *Adapter) log(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args []any, terminate bool) {
+	if level <= levels.Disabled || level < a.target.Level() {
+		return
+	}
+
+	e := entry{time: t, level: level, err: err, msg: fmt.Sprintf(msg, args...)}
+
+	if logFields != nil {
+		e.fields = logFields.Copy()
+	}
+
+	if level >= levels.Fatal {
+		_ = a.Flush(context.Background())
+
+		if target, ok := a.target.(emitter); ok && !terminate {
+			target.Emit(e.time, e.level, e.err, e.fields, "%s", e.msg)
+			return
+		}
+
+		a.write(e)
+
+		return
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if a.closed {
+		a.write(e)
+		return
+	}
+
+	a.enqueue(e)
+}
+
+// Flush waits until all the messages buffered before the call are written, or until the context is done.
+func (a *Adapter) Flush(ctx context.Context) error {
+	target := a.accepted.Load()
+
+	for {
+		a.progressM.Lock()
+
+		if a.processed >= target {
+			a.progressM.Unlock()
+			return nil
+		}
+
+		if a.progress == nil {
+			a.progress = make(chan struct{})
+		}
+
+		progress := a.progress
+		a.progressM.Unlock()
+
+		select {
+		case <-progress:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Close stops accepting messages on the buffer and waits until all the buffered messages are written. Messages
+// logged after closing the adapter are written synchronously.
+func (a *Adapter) Close() error {
+	a.mutex.Lock()
+
+	if a.closed {
+		a.mutex.Unlock()
+		return nil
+	}
+
+	a.closed = true
+	close(a.queue)
+	a.mutex.Unlock()
+
+	<-a.done
+
+	return nil
+}
+
+func (a *Adapter) enqueue(e entry) {
+	switch a.policy {
+	case DropNewest:
+		a.sendOrDrop(e)
+	case DropOldest:
+		a.accepted.Add(1)
+
+		for {
+			select {
+			case a.queue <- e:
+				return
+			default:
+			}
+
+			select {
+			case <-a.queue:
+				a.dropped.Add(1)
+				a.markProcessed()
+			default:
+			}
+		}
+	case DropBelowLevel:
+		if e.level < a.dropLevel {
+			a.sendOrDrop(e)
+			return
+		}
+
+		a.accepted.Add(1)
+		a.queue <- e
+	default:
+		a.accepted.Add(1)
+		a.queue <- e
+	}
+}
+
+func (a *Adapter) sendOrDrop(e entry) {
+	a.accepted.Add(1)
+
+	select {
+	case a.queue <- e:
+	default:
+		a.dropped.Add(1)
+		a.markProcessed()
+	}
+}
+
+func (a *Adapter) run(reportInterval time.Duration) {
+	defer close(a.done)
+
+	var tick <-chan time.Time
+
+	if reportInterval > 0 {
+		ticker := time.NewTicker(reportInterval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case e, ok := <-a.queue:
+			if !ok {
+				a.reportDropped()
+				return
+			}
+
+			a.write(e)
+			a.markProcessed()
+		case <-tick:
+			a.reportDropped()
+		}
+	}
+}
+
+func (a *Adapter) write(e entry) {
+	if timed, ok := a.target.(timedTarget); ok {
+		timed.LogAt(e.time, e.level, e.err, e.fields, "%s", e.msg)
+		return
+	}
+
+	a.target.Log(e.level, e.err, e.fields, "%s", e.msg)
+}
+
+func (a *Adapter) markProcessed() {
+	a.progressM.Lock()
+	defer a.progressM.Unlock()
+
+	a.processed++
+
+	// the progress channel only exists while a Flush call is waiting, and it is closed to wake it up
+	if a.progress != nil {
+		close(a.progress)
+		a.progress = nil
+	}
+}
+
+func (a *Adapter) reportDropped() {
+	total := a.dropped.Load()
+	count := total - a.reported.Swap(total)
+
+	if count == 0 {
+		return
+	}
+
+	a.target.Log(levels.Warn, nil, fields.New().Set(DroppedField, count), "async: dropped %d log entries", count)
+}
@@ -0,0 +1,408 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/native"
+	"github.com/danteay/golog/adaptertest"
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+type record struct {
+	level  levels.Level
+	err    error
+	fields map[string]any
+	msg    string
+}
+
+type recorder struct {
+	mutex   sync.Mutex
+	level   levels.Level
+	writer  io.Writer
+	gate    chan struct{}
+	records []record
+}
+
+func newRecorder(level levels.Level) *recorder {
+	return &recorder{level: level, gate: make(chan struct{})}
+}
+
+func (r *recorder) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level != levels.Warn || logFields == nil || logFields.Get(DroppedField) == nil {
+		<-r.gate
+	}
+
+	rec := record{level: level, err: err, msg: fmt.Sprintf(msg, args...)}
+
+	if logFields != nil {
+		rec.fields = logFields.Data()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.records = append(r.records, rec)
+}
+
+func (r *recorder) Writer() io.Writer { return r.writer }
+
+func (r *recorder) SetWriter(w io.Writer) { r.writer = w }
+
+func (r *recorder) Level() levels.Level { return r.level }
+
+func (r *recorder) SetLevel(level levels.Level) { r.level = level }
+
+func (r *recorder) open() { close(r.gate) }
+
+type timedRecorder struct {
+	*recorder
+	times []time.Time
+}
+
+func (r *timedRecorder) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	r.times = append(r.times, t)
+	r.Log(level, err, logFields, msg, args...)
+}
+
+func (r *recorder) messages() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	msgs := make([]string, 0, len(r.records))
+	for _, rec := range r.records {
+		msgs = append(msgs, rec.msg)
+	}
+
+	return msgs
+}
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should write messages asynchronously", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target)
+
+		logFields := fields.New().Set("key1", "value1")
+		err := errors.New("test error")
+
+		logger.Log(levels.Info, err, logFields, "Test %s", "message")
+		logFields.Set("key1", "changed")
+
+		assert.Empty(t, target.messages())
+
+		target.open()
+		assert.NoError(t, logger.Close())
+
+		assert.Equal(t, []string{"Test message"}, target.messages())
+		assert.Equal(t, levels.Info, target.records[0].level)
+		assert.Equal(t, err, target.records[0].err)
+		assert.Equal(t, map[string]any{"key1": "value1"}, target.records[0].fields)
+	})
+
+	t.Run("should not buffer messages under level", func(t *testing.T) {
+		target := newRecorder(levels.Info)
+		target.open()
+
+		logger := New(target)
+		logger.Log(levels.Debug, nil, nil, "debug message")
+
+		assert.NoError(t, logger.Close())
+		assert.Empty(t, target.messages())
+	})
+
+	t.Run("should drop newest messages", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target, WithBufferSize(2), WithOverflowPolicy(DropNewest), WithDropReportInterval(0))
+
+		fillBuffer(t, logger, 4)
+
+		target.open()
+		assert.NoError(t, logger.Close())
+
+		assert.Equal(t, []string{"message 0", "message 1", "message 2", "async: dropped 2 log entries"}, target.messages())
+		assert.Equal(t, uint64(2), logger.Dropped())
+		assert.Equal(t, uint64(2), target.records[3].fields[DroppedField])
+	})
+
+	t.Run("should drop oldest messages", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target, WithBufferSize(2), WithOverflowPolicy(DropOldest), WithDropReportInterval(0))
+
+		fillBuffer(t, logger, 4)
+
+		target.open()
+		assert.NoError(t, logger.Close())
+
+		assert.Equal(t, []string{"message 0", "message 3", "message 4", "async: dropped 2 log entries"}, target.messages())
+	})
+
+	t.Run("should drop messages below level", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target, WithBufferSize(2), WithOverflowPolicy(DropBelowLevel), WithDropLevel(levels.Warn), WithDropReportInterval(0))
+
+		fillBuffer(t, logger, 2)
+
+		logger.Log(levels.Info, nil, nil, "info message")
+
+		written := make(chan struct{})
+
+		go func() {
+			logger.Log(levels.Error, nil, nil, "error message")
+			close(written)
+		}()
+
+		select {
+		case <-written:
+			t.Fatal("Expected error message to block until there is room in the buffer")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		target.open()
+		<-written
+
+		assert.NoError(t, logger.Close())
+		assert.Equal(t, []string{"message 0", "message 1", "message 2", "error message", "async: dropped 1 log entries"}, target.messages())
+	})
+
+	t.Run("should report dropped messages periodically", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target, WithBufferSize(1), WithOverflowPolicy(DropNewest), WithDropReportInterval(time.Millisecond))
+
+		fillBuffer(t, logger, 2)
+
+		target.open()
+
+		assert.Eventually(t, func() bool {
+			return len(target.messages()) == 3
+		}, time.Second, time.Millisecond)
+
+		assert.ElementsMatch(t, []string{"message 0", "message 1", "async: dropped 1 log entries"}, target.messages())
+		assert.NoError(t, logger.Close())
+	})
+
+	t.Run("should write synchronously after close", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		target.open()
+
+		logger := New(target)
+		assert.NoError(t, logger.Close())
+		assert.NoError(t, logger.Close())
+
+		logger.Log(levels.Info, nil, nil, "Test message")
+
+		assert.Equal(t, []string{"Test message"}, target.messages())
+	})
+
+	t.Run("should flush before fatal messages", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target)
+
+		logger.Log(levels.Info, nil, nil, "info message")
+
+		target.open()
+		logger.Log(levels.Fatal, nil, nil, "fatal message")
+
+		assert.Equal(t, []string{"info message", "fatal message"}, target.messages())
+		assert.NoError(t, logger.Close())
+	})
+}
+
+func TestAdapter_LogAt(t *testing.T) {
+	target := &timedRecorder{recorder: newRecorder(levels.Debug)}
+	target.open()
+
+	logger := New(target)
+
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger.LogAt(ts, levels.Info, nil, nil, "info message")
+
+	assert.NoError(t, logger.Close())
+	assert.Equal(t, []time.Time{ts}, target.times)
+	assert.Equal(t, []string{"info message"}, target.messages())
+}
+
+func TestAdapter_Flush(t *testing.T) {
+	t.Run("should wait for buffered messages", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target)
+		defer logger.Close()
+
+		logger.Log(levels.Info, nil, nil, "message 1")
+		logger.Log(levels.Info, nil, nil, "message 2")
+
+		target.open()
+
+		assert.NoError(t, logger.Flush(context.Background()))
+		assert.Equal(t, []string{"message 1", "message 2"}, target.messages())
+	})
+
+	t.Run("should stop waiting when context is done", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target)
+
+		logger.Log(levels.Info, nil, nil, "message 1")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, logger.Flush(ctx), context.DeadlineExceeded)
+
+		target.open()
+		assert.NoError(t, logger.Close())
+	})
+
+	t.Run("should not leave goroutines behind when context is done", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target)
+
+		logger.Log(levels.Info, nil, nil, "message 1")
+
+		goroutines := runtime.NumGoroutine()
+
+		for i := 0; i < 10; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			assert.ErrorIs(t, logger.Flush(ctx), context.DeadlineExceeded)
+			cancel()
+		}
+
+		assert.Equal(t, goroutines, runtime.NumGoroutine())
+
+		target.open()
+		assert.NoError(t, logger.Close())
+	})
+}
+
+type exitRecorder struct {
+	*recorder
+	exitFunc func(code int)
+}
+
+func (r *exitRecorder) SetExitFunc(exitFunc func(code int)) { r.exitFunc = exitFunc }
+
+type emitRecorder struct {
+	*recorder
+	emitted []string
+}
+
+func (r *emitRecorder) Emit(_ time.Time, _ levels.Level, _ error, _ *fields.Fields, msg string, args ...any) {
+	r.emitted = append(r.emitted, fmt.Sprintf(msg, args...))
+}
+
+func TestAdapter_Emit(t *testing.T) {
+	target := &emitRecorder{recorder: newRecorder(levels.Debug)}
+	target.open()
+
+	logger := New(target)
+	defer logger.Close()
+
+	logger.Emit(time.Now(), levels.Info, nil, nil, "info message")
+	logger.Emit(time.Now(), levels.Fatal, nil, nil, "fatal message")
+
+	assert.Equal(t, []string{"info message"}, target.messages(), "the buffered messages must be flushed first")
+	assert.Equal(t, []string{"fatal message"}, target.emitted)
+}
+
+func TestAdapter_SetExitFunc(t *testing.T) {
+	t.Run("should set the exit function of the wrapped adapter", func(t *testing.T) {
+		target := &exitRecorder{recorder: newRecorder(levels.Debug)}
+		logger := New(target)
+		defer logger.Close()
+
+		var codes []int
+
+		logger.SetExitFunc(func(code int) { codes = append(codes, code) })
+
+		if assert.NotNil(t, target.exitFunc) {
+			target.exitFunc(2)
+		}
+
+		assert.Equal(t, []int{2}, codes)
+	})
+
+	t.Run("should ignore wrapped adapters without exit function", func(t *testing.T) {
+		target := newRecorder(levels.Debug)
+		logger := New(target)
+		defer logger.Close()
+
+		assert.NotPanics(t, func() {
+			logger.SetExitFunc(func(int) {})
+		})
+	})
+}
+
+func TestAdapter_Level(t *testing.T) {
+	target := newRecorder(levels.Info)
+	target.open()
+
+	logger := New(target)
+	defer logger.Close()
+
+	logger.SetLevel(levels.Warn)
+
+	assert.Equal(t, levels.Warn, logger.Level())
+	assert.Equal(t, levels.Warn, target.Level())
+}
+
+func TestAdapter_ConcurrentLog(t *testing.T) {
+	target := newRecorder(levels.Debug)
+	target.open()
+
+	logger := New(target, WithBufferSize(10))
+
+	const goroutines = 100
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			logger.Log(levels.Info, nil, nil, "message")
+		}()
+	}
+
+	wg.Wait()
+
+	assert.NoError(t, logger.Close())
+	assert.Len(t, target.messages(), goroutines)
+}
+
+// fillBuffer logs a first message that blocks the worker on the target, and then logs size more messages.
+func fillBuffer(t *testing.T, logger *Adapter, size int) {
+	t.Helper()
+
+	logger.Log(levels.Info, nil, nil, "message 0")
+
+	assert.Eventually(t, func() bool {
+		return len(logger.queue) == 0
+	}, time.Second, time.Millisecond)
+
+	for i := 1; i <= size; i++ {
+		logger.Log(levels.Info, nil, nil, "message %d", i)
+	}
+}
+
+func TestAdapter_Conformance(t *testing.T) {
+	adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+		adapter := New(native.New(
+			native.WithWriter(config.Writer),
+			native.WithLevel(config.Level),
+			native.WithExitFunc(config.ExitFunc),
+		))
+
+		t.Cleanup(func() {
+			_ = adapter.Close()
+		})
+
+		return adapter
+	})
+}
@@ -0,0 +1,66 @@
+package async
+
+import (
+	"time"
+
+	"github.com/danteay/golog/levels"
+)
+
+// OverflowPolicy defines what happens with a new log message when the buffer is full.
+type OverflowPolicy int8
+
+const (
+	// Block waits until there is room in the buffer.
+	Block OverflowPolicy = iota
+	// DropNewest discards the new message.
+	DropNewest
+	// DropOldest discards the oldest buffered message to make room for the new one.
+	DropOldest
+	// DropBelowLevel discards the new message if its level is below the configured drop level, otherwise it waits
+	// until there is room in the buffer.
+	DropBelowLevel
+)
+
+type options struct {
+	bufferSize     int
+	policy         OverflowPolicy
+	dropLevel      levels.Level
+	reportInterval time.Duration
+}
+
+// Option defines the signature for the options.
+type Option func(*options)
+
+// WithBufferSize sets the maximum number of buffered log messages.
+func WithBufferSize(size int) Option {
+	return func(opts *options) {
+		if size <= 0 {
+			return
+		}
+
+		opts.bufferSize = size
+	}
+}
+
+// WithOverflowPolicy sets the policy applied to new log messages when the buffer is full.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(opts *options) {
+		opts.policy = policy
+	}
+}
+
+// WithDropLevel sets the level under which messages are discarded when the buffer is full and the DropBelowLevel
+// policy is used.
+func WithDropLevel(level levels.Level) Option {
+	return func(opts *options) {
+		opts.dropLevel = level
+	}
+}
+
+// WithDropReportInterval sets how often the number of discarded messages is logged. A zero interval disables the
+// periodic report, the discarded messages are still reported when the adapter is closed.
+func WithDropReportInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.reportInterval = interval
+	}
+}
@@ -0,0 +1,51 @@
+package async
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danteay/golog/levels"
+)
+
+func TestWithBufferSize(t *testing.T) {
+	opts := &options{bufferSize: 10}
+
+	WithBufferSize(5)(opts)
+
+	if opts.bufferSize != 5 {
+		t.Errorf("Expected buffer size to be 5, but got %d", opts.bufferSize)
+	}
+
+	WithBufferSize(0)(opts)
+
+	if opts.bufferSize != 5 {
+		t.Errorf("Expected buffer size to be 5, but got %d", opts.bufferSize)
+	}
+}
+
+func TestWithOverflowPolicy(t *testing.T) {
+	opts := &options{}
+	WithOverflowPolicy(DropOldest)(opts)
+
+	if opts.policy != DropOldest {
+		t.Errorf("Expected policy to be DropOldest, but got %v", opts.policy)
+	}
+}
+
+func TestWithDropLevel(t *testing.T) {
+	opts := &options{}
+	WithDropLevel(levels.Warn)(opts)
+
+	if opts.dropLevel != levels.Warn {
+		t.Errorf("Expected drop level to be Warn, but got %v", opts.dropLevel)
+	}
+}
+
+func TestWithDropReportInterval(t *testing.T) {
+	opts := &options{}
+	WithDropReportInterval(time.Minute)(opts)
+
+	if opts.reportInterval != time.Minute {
+		t.Errorf("Expected report interval to be 1m, but got %v", opts.reportInterval)
+	}
+}
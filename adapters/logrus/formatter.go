@@ -0,0 +1,51 @@
+package logrus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/danteay/golog/logencoding"
+)
+
+// jsonFormatter is a logrus formatter that writes every entry as a JSON object with the keys, level casing and time
+// format of the encoding config. As the logrus.JSONFormatter, fields that clash with the time, level or message keys
+// are written with the "fields." prefix.
+type jsonFormatter struct {
+	config logencoding.Config
+}
+
+// Format renders a single log entry.
+func (f *jsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(entry.Data)+3)
+
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+
+		switch key {
+		case f.config.TimeKey, f.config.LevelKey, f.config.MessageKey:
+			key = "fields." + key
+		}
+
+		data[key] = value
+	}
+
+	data[f.config.TimeKey] = f.config.Time(entry.Time)
+	data[f.config.LevelKey] = f.config.Level(getGologLevel(entry.Level))
+	data[f.config.MessageKey] = entry.Message
+
+	buf := entry.Buffer
+	if buf == nil {
+		buf = &bytes.Buffer{}
+	}
+
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		return nil, fmt.Errorf("failed to marshal fields to JSON: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
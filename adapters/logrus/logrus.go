@@ -0,0 +1,255 @@
+package logrus
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+// Adapter is a logrus adapter implementation
+type Adapter struct {
+	mutex     *sync.Mutex
+	logger    *logrus.Logger
+	level     *atomic.Int32
+	config    logencoding.Config
+	withTrace bool
+}
+
+func New(opts ...Option) *Adapter {
+	var logOpts options
+
+	for _, opt := range opts {
+		opt(&logOpts)
+	}
+
+	config := logencoding.New()
+	if logOpts.config != nil {
+		config = *logOpts.config
+	}
+
+	logger := logOpts.logger
+	if logger == nil {
+		logger = logrus.New()
+		logger.SetOutput(os.Stdout)
+		logger.SetFormatter(&jsonFormatter{config: config})
+
+		if logOpts.level == 0 {
+			logOpts.level = levels.Info
+		}
+	}
+
+	if logOpts.writer != nil {
+		logger.SetOutput(logOpts.writer)
+	}
+
+	if logOpts.exitFunc != nil {
+		logger.ExitFunc = logOpts.exitFunc
+	}
+
+	if logOpts.logger != nil && logOpts.config != nil {
+		logger.SetFormatter(&jsonFormatter{config: config})
+	}
+
+	if logOpts.formatter != nil {
+		logger.SetFormatter(logOpts.formatter)
+	}
+
+	if logOpts.colored {
+		logger.SetFormatter(&logrus.TextFormatter{ForceColors: true, FullTimestamp: true})
+	}
+
+	adapter := &Adapter{
+		mutex:     &sync.Mutex{},
+		logger:    logger,
+		level:     &atomic.Int32{},
+		config:    config,
+		withTrace: logOpts.withTrace,
+	}
+
+	adapter.level.Store(int32(getGologLevel(logger.GetLevel())))
+
+	if logOpts.level != 0 {
+		adapter.SetLevel(logOpts.level)
+	}
+
+	return adapter
+}
+
+// Writer returns the writer for the adapter
+func (a *Adapter) Writer() io.Writer {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.logger.Out
+}
+
+// SetWriter sets the writer for the adapter
+func (a *Adapter) SetWriter(w io.Writer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.logger.SetOutput(w)
+}
+
+// Level returns the level for the adapter
+func (a *Adapter) Level() levels.Level {
+	return levels.Level(a.level.Load())
+}
+
+// SetLevel sets the level for the adapter
+func (a *Adapter) SetLevel(level levels.Level) {
+	a.level.Store(int32(level))
+	a.logger.SetLevel(getLevels(level))
+}
+
+// SetExitFunc sets the exit function of the logrus logger, that is called with the exit code after writing Fatal
+// messages.
+func (a *Adapter) SetExitFunc(exitFunc func(code int)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.logger.ExitFunc = exitFunc
+}
+
+// Logger returns the logrus logger instance
+func (a *Adapter) Logger() *logrus.Logger {
+	return a.logger
+}
+
+// Log logs a message with the given level, error, fields, and message
+func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt logs a message with the given timestamp, level, error, fields, and message. The hooks registered on the
+// logrus logger are fired for every written message. Fatal messages exit the program with the logger exit function
+// and Panic messages panic after being written, with the error when it is set and with the message otherwise.
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	message := a.emit(t, level, err, logFields, msg, args)
+
+	switch {
+	case level == levels.Fatal:
+		a.exit(1)
+	case level == levels.Panic && err != nil:
+		panic(err)
+	case level == levels.Panic:
+		panic(message)
+	}
+}
+
+// exit runs the logrus exit handlers and calls the exit function of the logger, holding the mutex so the exit function
+// is not replaced by SetExitFunc while it is read.
+func (a *Adapter) exit(code int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.logger.Exit(code)
+}
+
+// Emit writes a message with the given timestamp, level, error, fields, and message, without exiting the program
+// after Fatal messages or panicking after Panic messages, so the caller can terminate on its own.
+func (a *Adapter) Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	a.emit(t, level, err, logFields, msg, args)
+}
+
+func (a *Adapter) emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args []any) string {
+	message := fmt.Sprintf(msg, args...)
+
+	if adapterLevel := a.Level(); adapterLevel != levels.Disabled && level >= adapterLevel {
+		entry := a.logger.WithTime(t)
+
+		if logFields != nil {
+			entry = entry.WithFields(logrus.Fields(logFields.Data()))
+		}
+
+		entry = a.addErrFields(level, err, logFields, entry)
+
+		writeEntry(entry, getLevels(level), message)
+	}
+
+	return message
+}
+
+// writeEntry writes the entry with the given level. Panic entries panic inside logrus with the entry once they are
+// written, so the panic is recovered and the adapter panics with the error or the message as the rest of the adapters.
+func writeEntry(entry *logrus.Entry, level logrus.Level, message string) {
+	if level == logrus.PanicLevel {
+		defer func() {
+			_ = recover()
+		}()
+	}
+
+	entry.Log(level, message)
+}
+
+func (a *Adapter) addErrFields(level levels.Level, err error, logFields *fields.Fields, entry *logrus.Entry) *logrus.Entry {
+	if err == nil {
+		return entry
+	}
+
+	entry = entry.WithField(a.config.ErrorKey, err)
+
+	withStack := a.withTrace || level == levels.TraceLevel
+	if withStack && !logencoding.HasStack(logFields, a.config.StackKey, logencoding.DefaultStackKey) {
+		entry = entry.WithField(a.config.StackKey, logencoding.StackTrace())
+	}
+
+	return entry
+}
+
+func getLevels(level levels.Level) logrus.Level {
+	levelList := map[levels.Level]logrus.Level{
+		levels.NoLevel:    logrus.TraceLevel,
+		levels.Disabled:   logrus.PanicLevel,
+		levels.TraceLevel: logrus.TraceLevel,
+		levels.Debug:      logrus.DebugLevel,
+		levels.Info:       logrus.InfoLevel,
+		levels.Warn:       logrus.WarnLevel,
+		levels.Error:      logrus.ErrorLevel,
+		levels.Fatal:      logrus.FatalLevel,
+		levels.Panic:      logrus.PanicLevel,
+	}
+
+	ll, exists := levelList[level]
+	if !exists {
+		return logrus.InfoLevel
+	}
+
+	return ll
+}
+
+func getGologLevel(level logrus.Level) levels.Level {
+	levelList := map[logrus.Level]levels.Level{
+		logrus.TraceLevel: levels.TraceLevel,
+		logrus.DebugLevel: levels.Debug,
+		logrus.InfoLevel:  levels.Info,
+		logrus.WarnLevel:  levels.Warn,
+		logrus.ErrorLevel: levels.Error,
+		logrus.FatalLevel: levels.Fatal,
+		logrus.PanicLevel: levels.Panic,
+	}
+
+	gl, exists := levelList[level]
+	if !exists {
+		return levels.Info
+	}
+
+	return gl
+}
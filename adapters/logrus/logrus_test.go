@@ -0,0 +1,507 @@
+package logrus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adaptertest"
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+type testMsg struct {
+	Level   string   `json:"level"`
+	Message string   `json:"message"`
+	Error   string   `json:"error"`
+	Key1    string   `json:"key1"`
+	Key2    int      `json:"key2"`
+	Stack   []string `json:"stack"`
+}
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should log message", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		logFields := fields.New().SetMap(map[string]any{
+			"key1": "value1",
+			"key2": 42,
+		})
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.Debug, err, logFields, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, logFields.Get("key1"), res.Key1)
+		assert.Equal(t, logFields.Get("key2"), res.Key2)
+		assert.Equal(t, err.Error(), res.Error)
+	})
+
+	t.Run("should log message with no fields", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.Debug, err, nil, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, err.Error(), res.Error)
+	})
+
+	t.Run("should log message with no error", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		logFields := fields.New().SetMap(map[string]any{
+			"key1": "value1",
+			"key2": 42,
+		})
+
+		msg := "Test message"
+
+		logger.Log(levels.Debug, nil, logFields, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, logFields.Get("key1"), res.Key1)
+		assert.Equal(t, logFields.Get("key2"), res.Key2)
+		assert.Equal(t, "", res.Error)
+	})
+
+	t.Run("should log message with no fields and no error", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		msg := "Test message"
+
+		logger.Log(levels.Debug, nil, nil, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, "", res.Error)
+	})
+
+	t.Run("should not log message under level", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Info), WithWriter(&logOutput))
+
+		logFields := fields.New().SetMap(map[string]any{
+			"key1": "value1",
+			"key2": 42,
+		})
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.Debug, err, logFields, msg)
+
+		assert.Equal(t, "", logOutput.String())
+	})
+
+	t.Run("should log message with error and stack trace", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.TraceLevel), WithWriter(&logOutput))
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.TraceLevel, err, nil, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.TraceLevel.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, err.Error(), res.Error)
+		assert.NotEmpty(t, res.Stack)
+	})
+
+	t.Run("should change level", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Info), WithWriter(&logOutput))
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.Equal(t, "", logOutput.String())
+
+		logger.SetLevel(levels.Debug)
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.NotEqual(t, "", logOutput.String())
+	})
+
+	t.Run("should change writer", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.NotEqual(t, "", logOutput.String())
+
+		logOutput.Reset()
+
+		var newOutput bytes.Buffer
+
+		logger.SetWriter(&newOutput)
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.NotEqual(t, "", newOutput.String())
+		assert.Equal(t, "", logOutput.String())
+	})
+}
+
+func TestAdapter_LogAt(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger.LogAt(ts, levels.Info, nil, fields.New().Set("key1", "value1"), "Test %s", "message")
+
+	res := struct {
+		testMsg
+		Time time.Time `json:"time"`
+	}{}
+
+	if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+		t.Fatal(errUnmarshall)
+	}
+
+	assert.True(t, ts.Equal(res.Time))
+	assert.Equal(t, "Test message", res.Message)
+	assert.Equal(t, "value1", res.Key1)
+}
+
+type testHook struct {
+	entries []*logrus.Entry
+}
+
+func (h *testHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *testHook) Fire(entry *logrus.Entry) error {
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func TestAdapter_Encoding(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.FixedZone("CST", -6*60*60))
+	logFields := fields.New().Set("user", "john")
+
+	tests := []struct {
+		name     string
+		opts     []logencoding.Option
+		level    levels.Level
+		err      error
+		expected map[string]any
+	}{
+		{
+			name:  "should use the encoding defaults",
+			level: levels.Info,
+			expected: map[string]any{
+				"time":    "2024-01-02T03:04:05.006-06:00",
+				"level":   "info",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+		{
+			name: "should use custom keys, upper case levels and unix times",
+			opts: []logencoding.Option{
+				logencoding.WithTimeKey("ts"),
+				logencoding.WithLevelKey("severity"),
+				logencoding.WithMessageKey("msg"),
+				logencoding.WithErrorKey("err"),
+				logencoding.WithLevelCase(logencoding.Uppercase),
+				logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+			},
+			level: levels.Warn,
+			err:   errors.New("test error"),
+			expected: map[string]any{
+				"ts":       float64(ts.UnixMilli()),
+				"severity": "WARN",
+				"msg":      "Test message",
+				"err":      "test error",
+				"user":     "john",
+			},
+		},
+		{
+			name:  "should use a custom layout on UTC",
+			opts:  []logencoding.Option{logencoding.WithTimeFormat(time.RFC3339), logencoding.WithUTC()},
+			level: levels.Error,
+			expected: map[string]any{
+				"time":    "2024-01-02T09:04:05Z",
+				"level":   "error",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var logOutput bytes.Buffer
+
+			logger := New(WithWriter(&logOutput), WithEncoding(test.opts...))
+
+			logger.LogAt(ts, test.level, test.err, logFields, "Test %s", "message")
+
+			res := map[string]any{}
+
+			if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expected, res)
+		})
+	}
+
+	t.Run("should use the stack key", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(
+			WithLevel(levels.TraceLevel),
+			WithWriter(&logOutput),
+			WithEncoding(logencoding.WithStackKey("trace")),
+		)
+
+		logger.LogAt(ts, levels.TraceLevel, errors.New("test error"), nil, "Test message")
+
+		res := map[string]any{}
+
+		if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "trace", res["level"])
+		assert.NotEmpty(t, res["trace"])
+		assert.NotContains(t, res, "stack")
+	})
+}
+
+func TestJSONFormatter(t *testing.T) {
+	formatter := &jsonFormatter{config: logencoding.New(logencoding.WithTimeFormat(logencoding.TimeFormatUnix))}
+
+	entry := logrus.NewEntry(logrus.New()).WithFields(logrus.Fields{"message": "clash", "error": errors.New("test error")})
+	entry.Time = time.Unix(1577836800, 0)
+	entry.Level = logrus.InfoLevel
+	entry.Message = "Test message"
+
+	out, err := formatter.Format(entry)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"time":1577836800,"level":"info","message":"Test message","fields.message":"clash",`+
+		`"error":"test error"}`, string(out))
+}
+
+func TestAdapter_WithLogger(t *testing.T) {
+	t.Run("should keep logger configuration and hooks", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		hook := &testHook{}
+
+		base := logrus.New()
+		base.SetOutput(&logOutput)
+		base.SetFormatter(&logrus.TextFormatter{DisableColors: true, DisableTimestamp: true})
+		base.SetLevel(logrus.WarnLevel)
+		base.AddHook(hook)
+
+		logger := New(WithLogger(base))
+
+		assert.Equal(t, levels.Warn, logger.Level())
+
+		logger.Log(levels.Info, nil, nil, "Test message")
+
+		assert.Equal(t, "", logOutput.String())
+		assert.Empty(t, hook.entries)
+
+		logger.Log(levels.Warn, nil, fields.New().Set("key1", "value1"), "Test message")
+
+		assert.Equal(t, "level=warning msg=\"Test message\" key1=value1\n", logOutput.String())
+		assert.Len(t, hook.entries, 1)
+		assert.Equal(t, "value1", hook.entries[0].Data["key1"])
+	})
+
+	t.Run("should override logger level", func(t *testing.T) {
+		base := logrus.New()
+		base.SetLevel(logrus.WarnLevel)
+
+		logger := New(WithLogger(base), WithLevel(levels.Debug))
+
+		assert.Equal(t, levels.Debug, logger.Level())
+		assert.Equal(t, logrus.DebugLevel, base.GetLevel())
+	})
+}
+
+func TestAdapter_Fatal(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	exitCode := 0
+
+	base := logrus.New()
+	base.SetOutput(&logOutput)
+	base.ExitFunc = func(code int) {
+		exitCode = code
+	}
+
+	logger := New(WithLogger(base))
+
+	logger.Log(levels.Fatal, nil, nil, "Test message")
+
+	assert.Equal(t, 1, exitCode)
+	assert.NotEqual(t, "", logOutput.String())
+}
+
+func TestAdapter_Panic(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithLevel(levels.Disabled), WithWriter(&logOutput))
+
+	assert.PanicsWithValue(t, "Test message", func() {
+		logger.Log(levels.Panic, nil, nil, "Test message")
+	})
+
+	assert.Equal(t, "", logOutput.String())
+}
+
+func TestAdapter_PanicWritten(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithWriter(&logOutput))
+
+	assert.PanicsWithValue(t, "Test message", func() {
+		logger.Log(levels.Panic, nil, nil, "Test message")
+	})
+
+	assert.Contains(t, logOutput.String(), `"level":"panic"`)
+}
+
+func TestGetLevels(t *testing.T) {
+	tests := map[levels.Level]logrus.Level{
+		levels.TraceLevel: logrus.TraceLevel,
+		levels.Debug:      logrus.DebugLevel,
+		levels.Info:       logrus.InfoLevel,
+		levels.Warn:       logrus.WarnLevel,
+		levels.Error:      logrus.ErrorLevel,
+		levels.Fatal:      logrus.FatalLevel,
+		levels.Panic:      logrus.PanicLevel,
+	}
+
+	for level, expected := range tests {
+		actual := getLevels(level)
+		if actual != expected {
+			t.Errorf("Expected level to be %v, but got %v", expected, actual)
+		}
+
+		if gologLevel := getGologLevel(expected); gologLevel != level {
+			t.Errorf("Expected level to be %v, but got %v", level, gologLevel)
+		}
+	}
+
+	// Test an unsupported level
+	level := getLevels(levels.Level(42))
+	if level != logrus.InfoLevel {
+		t.Errorf("Expected level to be Info (default), but got %v", level)
+	}
+}
+
+func TestAdapter_SetExitFunc(t *testing.T) {
+	var (
+		logOutput bytes.Buffer
+		codes     []int
+	)
+
+	logger := New(WithWriter(&logOutput), WithExitFunc(func(int) {
+		t.Error("the replaced exit function was called")
+	}))
+
+	logger.SetExitFunc(func(code int) { codes = append(codes, code) })
+
+	logger.Log(levels.Fatal, nil, nil, "Test message")
+
+	assert.Equal(t, []int{1}, codes)
+	assert.Contains(t, logOutput.String(), "Test message")
+}
+
+func TestAdapter_Conformance(t *testing.T) {
+	t.Run("should pass with the default encoding", func(t *testing.T) {
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+			)
+		})
+	})
+
+	t.Run("should pass with a custom encoding", func(t *testing.T) {
+		encodingOpts := []logencoding.Option{
+			logencoding.WithMessageKey("msg"),
+			logencoding.WithLevelCase(logencoding.Uppercase),
+			logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+		}
+
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+				WithEncoding(encodingOpts...),
+			)
+		}, adaptertest.WithEncoding(logencoding.New(encodingOpts...)))
+	})
+}
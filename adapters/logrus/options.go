@@ -0,0 +1,89 @@
+package logrus
+
+import (
+	"io"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+type options struct {
+	level     levels.Level
+	writer    io.Writer
+	colored   bool
+	withTrace bool
+	formatter logrus.Formatter
+	config    *logencoding.Config
+	exitFunc  func(code int)
+	logger    *logrus.Logger
+}
+
+// Option defines the signature for the options.
+type Option func(*options)
+
+// WithLevel sets the log level for the logger.
+func WithLevel(level levels.Level) Option {
+	return func(opts *options) {
+		opts.level = level
+	}
+}
+
+// WithWriter sets the writer for the logger.
+func WithWriter(writer io.Writer) Option {
+	return func(opts *options) {
+		opts.writer = writer
+	}
+}
+
+// Colored sets the logger to use the logrus text formatter with colored output.
+func Colored() Option {
+	return func(opts *options) {
+		opts.colored = true
+	}
+}
+
+// WithTrace sets the error trace for the logger.
+func WithTrace() Option {
+	return func(opts *options) {
+		opts.withTrace = true
+	}
+}
+
+// WithFormatter sets the logrus formatter for the logger, e.g. &logrus.JSONFormatter{} or &logrus.TextFormatter{}.
+// Defaults to a JSON formatter that writes the keys and time format of the encoding config.
+func WithFormatter(formatter logrus.Formatter) Option {
+	return func(opts *options) {
+		opts.formatter = formatter
+	}
+}
+
+// WithLogger sets a preconfigured logrus logger to be used by the adapter, keeping its formatter, output and hooks.
+// When it is set, the adapter level is taken from the logger unless WithLevel is used, and the WithWriter, Colored,
+// WithFormatter, WithEncoding and WithExitFunc options are applied on top of the logger configuration.
+func WithLogger(logger *logrus.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// WithEncoding sets the key names, level casing and time format of the messages, e.g.
+// WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs)), writing them
+// as JSON objects. The settings that are not provided take the logencoding defaults, that are also used without this
+// option. When WithFormatter or Colored are set, their formatter is kept and only the error and stack keys are used, and
+// when WithLogger is set, its formatter is only replaced if this option is used.
+func WithEncoding(opts ...logencoding.Option) Option {
+	return func(o *options) {
+		config := logencoding.New(opts...)
+		o.config = &config
+	}
+}
+
+// WithExitFunc sets the exit function of the logrus logger, that is called with the exit code after writing Fatal
+// messages. Defaults to os.Exit.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(opts *options) {
+		opts.exitFunc = exitFunc
+	}
+}
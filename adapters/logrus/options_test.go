@@ -0,0 +1,113 @@
+package logrus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+func TestWithLevel(t *testing.T) {
+	opts := &options{}
+	WithLevel(levels.Debug)(opts)
+
+	if opts.level != levels.Debug {
+		t.Errorf("Expected level to be Debug, but got %v", opts.level)
+	}
+}
+
+func TestWithWriter(t *testing.T) {
+	opts := &options{}
+	writer := &bytes.Buffer{}
+	WithWriter(writer)(opts)
+
+	if opts.writer != writer {
+		t.Error("Expected writer to be the provided io.Writer, but it's not")
+	}
+}
+
+func TestColored(t *testing.T) {
+	opts := &options{}
+	Colored()(opts)
+
+	if !opts.colored {
+		t.Error("Expected colored to be true, but it's not")
+	}
+}
+
+func TestWithEncoding(t *testing.T) {
+	opts := &options{}
+	WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithLevelCase(logencoding.Uppercase))(opts)
+
+	if opts.config.MessageKey != "msg" || opts.config.LevelCase != logencoding.Uppercase {
+		t.Errorf("Expected custom encoding config, but got %+v", opts.config)
+	}
+
+	if opts.config.TimeKey != logencoding.DefaultTimeKey {
+		t.Errorf("Expected default time key, but got %q", opts.config.TimeKey)
+	}
+}
+
+func TestWithExitFunc(t *testing.T) {
+	opts := &options{}
+	code := 0
+	WithExitFunc(func(c int) { code = c })(opts)
+
+	opts.exitFunc(1)
+
+	if code != 1 {
+		t.Errorf("Expected exit function to be called with 1, but got %d", code)
+	}
+}
+
+func TestOptionChaining(t *testing.T) {
+	opts := &options{}
+	WithLevel(levels.Error)(opts)
+	writer := &bytes.Buffer{}
+	WithWriter(writer)(opts)
+	Colored()(opts)
+
+	if opts.level != levels.Error {
+		t.Errorf("Expected level to be Error, but got %v", opts.level)
+	}
+
+	if opts.writer != writer {
+		t.Error("Expected writer to be the provided io.Writer, but it's not")
+	}
+
+	if !opts.colored {
+		t.Error("Expected colored to be true, but it's not")
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	opts := &options{}
+	WithTrace()(opts)
+
+	if !opts.withTrace {
+		t.Error("Expected withTrace to be true, but it's not")
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	opts := &options{}
+	logger := logrus.New()
+	WithLogger(logger)(opts)
+
+	if opts.logger != logger {
+		t.Error("Expected logger to be the provided logrus logger, but it's not")
+	}
+}
+
+func TestWithFormatter(t *testing.T) {
+	opts := &options{}
+	formatter := &logrus.TextFormatter{}
+	WithFormatter(formatter)(opts)
+
+	if opts.formatter != formatter {
+		t.Error("Expected formatter to be the provided formatter, but it's not")
+	}
+}
@@ -0,0 +1,310 @@
+package multi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+// Target is the interface that the wrapped adapters should implement. It is the same as golog.Adapter.
+type Target interface {
+	Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+	Writer() io.Writer
+	SetWriter(w io.Writer)
+	Level() levels.Level
+	SetLevel(level levels.Level)
+}
+
+type exitFuncSetter interface {
+	SetExitFunc(exitFunc func(code int))
+}
+
+type flusher interface {
+	Flush(ctx context.Context) error
+}
+
+type timedTarget interface {
+	LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+type emitter interface {
+	Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+// Adapter is an adapter implementation that sends every log message to several adapters, each one with its own
+// level threshold.
+type Adapter struct {
+	mutex    *sync.RWMutex
+	targets  []target
+	exitFunc func(code int)
+}
+
+// New creates a new multi adapter with the configured targets. The target adapters are not changed, so they keep
+// filtering the messages with their own level on top of their threshold.
+func New(opts ...Option) *Adapter {
+	logOpts := options{}
+
+	for _, opt := range opts {
+		opt(&logOpts)
+	}
+
+	if logOpts.exitFunc == nil {
+		logOpts.exitFunc = os.Exit
+	}
+
+	return &Adapter{
+		mutex:    &sync.RWMutex{},
+		targets:  logOpts.targets,
+		exitFunc: logOpts.exitFunc,
+	}
+}
+
+// Writer returns a writer that writes to the writers of all the targets. A write error on one target does not stop
+// the write on the others, and the first error is returned. When all the targets share the same writer, e.g. after
+// calling SetWriter, that writer is returned as is.
+func (a *Adapter) Writer() io.Writer {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	writers := make([]io.Writer, 0, len(a.targets))
+
+	for _, t := range a.targets {
+		writers = append(writers, t.adapter.Writer())
+	}
+
+	if len(writers) > 0 && sameWriter(writers) {
+		return writers[0]
+	}
+
+	return multiWriter(writers)
+}
+
+// sameWriter reports whether all the writers are the same value. Writers of types that cannot be compared are never
+// considered the same.
+func sameWriter(writers []io.Writer) bool {
+	for _, w := range writers {
+		if w == nil || !reflect.TypeOf(w).Comparable() || w != writers[0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetWriter sets the writer for all the targets.
+func (a *Adapter) SetWriter(w io.Writer) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	for _, t := range a.targets {
+		t.adapter.SetWriter(w)
+	}
+}
+
+// Level returns the lowest level of all the targets, which is the lowest level that is written by at least one of
+// them. If there are no targets it returns levels.Disabled.
+func (a *Adapter) Level() levels.Level {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	level := levels.Disabled
+
+	for _, t := range a.targets {
+		if t.level <= levels.Disabled {
+			continue
+		}
+
+		if level == levels.Disabled || t.level < level {
+			level = t.level
+		}
+	}
+
+	return level
+}
+
+// SetLevel sets the same level threshold for all the targets.
+func (a *Adapter) SetLevel(level levels.Level) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	targets := make([]target, 0, len(a.targets))
+
+	for _, t := range a.targets {
+		t.adapter.SetLevel(level)
+		targets = append(targets, target{adapter: t.adapter, level: level})
+	}
+
+	a.targets = targets
+}
+
+// SetExitFunc sets the function called with the exit code after writing Fatal messages, along with the exit function
+// of the targets that exit the program on their own.
+func (a *Adapter) SetExitFunc(exitFunc func(code int)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.exitFunc = exitFunc
+
+	for _, t := range a.targets {
+		if setter, ok := t.adapter.(exitFuncSetter); ok {
+			setter.SetExitFunc(exitFunc)
+		}
+	}
+}
+
+// Flush flushes the targets that write the messages in the background. A flush error on one target does not stop
+// the flush of the others, and the first error is returned.
+func (a *Adapter) Flush(ctx context.Context) error {
+	a.mutex.RLock()
+	targets := a.targets
+	a.mutex.RUnlock()
+
+	var firstErr error
+
+	for _, t := range targets {
+		f, ok := t.adapter.(flusher)
+		if !ok {
+			continue
+		}
+
+		if err := f.Flush(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Log sends the message to every target whose level threshold allows it. A panic on one target does not prevent
+// the delivery to the others, the first panic is raised again once all the targets were called.
+func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt sends the message with the given timestamp to every target whose level threshold allows it. Targets that
+// can't log with a given timestamp log the message with the current time.
+//
+// Fatal and Panic messages are delivered to all the targets before terminating: targets with an Emit method write
+// them without terminating, the rest are called last, and then the adapter exits with its exit function or panics,
+// with the error when it is set and with the message otherwise.
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	recovered := a.deliver(t, level, err, logFields, msg, args)
+
+	switch {
+	case recovered != nil:
+		panic(recovered)
+	case level == levels.Fatal:
+		a.mutex.RLock()
+		exitFunc := a.exitFunc
+		a.mutex.RUnlock()
+
+		exitFunc(1)
+	case level == levels.Panic && err != nil:
+		panic(err)
+	case level == levels.Panic:
+		panic(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Emit sends the message with the given timestamp to every target whose level threshold allows it, as LogAt does,
+// without exiting the program after Fatal messages or panicking after Panic messages, so the caller can terminate on
+// its own.
+func (a *Adapter) Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	if recovered := a.deliver(t, level, err, logFields, msg, args); recovered != nil && level < levels.Fatal {
+		panic(recovered)
+	}
+}
+
+// deliver sends the message to the targets and returns the first panic raised by them. Fatal and Panic messages are
+// sent to the targets without an Emit method last, as they may terminate the program.
+func (a *Adapter) deliver(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args []any) any {
+	a.mutex.RLock()
+	targets := a.targets
+	a.mutex.RUnlock()
+
+	var (
+		recovered   any
+		terminating []Target
+	)
+
+	for _, tg := range targets {
+		if tg.level <= levels.Disabled || level < tg.level {
+			continue
+		}
+
+		if _, ok := tg.adapter.(emitter); !ok && level >= levels.Fatal {
+			terminating = append(terminating, tg.adapter)
+			continue
+		}
+
+		if r := logTarget(tg.adapter, t, level, err, logFields, msg, args...); r != nil && recovered == nil {
+			recovered = r
+		}
+	}
+
+	for _, adapter := range terminating {
+		if r := logTarget(adapter, t, level, err, logFields, msg, args...); r != nil && recovered == nil {
+			recovered = r
+		}
+	}
+
+	return recovered
+}
+
+func logTarget(adapter Target, t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) (recovered any) {
+	defer func() {
+		recovered = recover()
+	}()
+
+	if e, ok := adapter.(emitter); ok {
+		e.Emit(t, level, err, logFields, msg, args...)
+		return nil
+	}
+
+	if timed, ok := adapter.(timedTarget); ok {
+		timed.LogAt(t, level, err, logFields, msg, args...)
+		return nil
+	}
+
+	adapter.Log(level, err, logFields, msg, args...)
+
+	return nil
+}
+
+type multiWriter []io.Writer
+
+func (mw multiWriter) Write(p []byte) (int, error) {
+	var firstErr error
+
+	for _, w := range mw {
+		n, err := w.Write(p)
+		if err == nil && n != len(p) {
+			err = io.ErrShortWrite
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return 0, firstErr
+	}
+
+	return len(p), nil
+}
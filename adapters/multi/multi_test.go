@@ -0,0 +1,361 @@
+package multi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/native"
+	"github.com/danteay/golog/adaptertest"
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+type record struct {
+	level levels.Level
+	err   error
+	msg   string
+}
+
+type recorder struct {
+	mutex   sync.Mutex
+	level   levels.Level
+	writer  io.Writer
+	records []record
+	panics  bool
+	onLog   func()
+}
+
+func (r *recorder) Log(level levels.Level, err error, _ *fields.Fields, msg string, _ ...any) {
+	r.mutex.Lock()
+	r.records = append(r.records, record{level: level, err: err, msg: msg})
+	r.mutex.Unlock()
+
+	if r.onLog != nil {
+		r.onLog()
+	}
+
+	if r.panics {
+		panic("recorder panic")
+	}
+}
+
+func (r *recorder) Writer() io.Writer { return r.writer }
+
+func (r *recorder) SetWriter(w io.Writer) { r.writer = w }
+
+func (r *recorder) Level() levels.Level { return r.level }
+
+func (r *recorder) SetLevel(level levels.Level) { r.level = level }
+
+type timedRecorder struct {
+	recorder
+	times []time.Time
+}
+
+func (r *timedRecorder) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	r.times = append(r.times, t)
+	r.Log(level, err, logFields, msg, args...)
+}
+
+type terminatingRecorder struct {
+	recorder
+	exitFunc func(code int)
+	emitted  []record
+	flushErr error
+	flushed  int
+}
+
+func (r *terminatingRecorder) SetExitFunc(exitFunc func(code int)) { r.exitFunc = exitFunc }
+
+func (r *terminatingRecorder) Emit(_ time.Time, level levels.Level, err error, _ *fields.Fields, msg string, _ ...any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.emitted = append(r.emitted, record{level: level, err: err, msg: msg})
+}
+
+func (r *terminatingRecorder) Flush(_ context.Context) error {
+	r.flushed++
+	return r.flushErr
+}
+
+type failWriter struct{}
+
+func (failWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should send messages according target levels", func(t *testing.T) {
+		debug := &recorder{}
+		info := &recorder{}
+		errs := &recorder{}
+
+		logger := New(
+			WithTarget(debug, levels.Debug),
+			WithTarget(info, levels.Info),
+			WithTarget(errs, levels.Error),
+		)
+
+		logger.Log(levels.Debug, nil, nil, "debug message")
+		logger.Log(levels.Info, nil, nil, "info message")
+		logger.Log(levels.Error, nil, nil, "error message")
+
+		assert.Len(t, debug.records, 3)
+		assert.Len(t, info.records, 2)
+		assert.Len(t, errs.records, 1)
+		assert.Equal(t, "error message", errs.records[0].msg)
+	})
+
+	t.Run("should not change target adapters level", func(t *testing.T) {
+		debug := &recorder{level: levels.Info}
+
+		New(WithTarget(debug, levels.Debug))
+
+		assert.Equal(t, levels.Info, debug.Level())
+	})
+
+	t.Run("should deliver fatal messages to all the targets before exiting", func(t *testing.T) {
+		var codes []int
+
+		emitting := &terminatingRecorder{}
+		exiting := &recorder{}
+		exiting.onLog = func() {
+			assert.Len(t, emitting.emitted, 1, "targets that may exit must be called last")
+		}
+
+		logger := New(
+			WithTarget(exiting, levels.Info),
+			WithTarget(emitting, levels.Info),
+			WithExitFunc(func(code int) { codes = append(codes, code) }),
+		)
+
+		logger.Log(levels.Fatal, nil, nil, "fatal message")
+
+		assert.Len(t, exiting.records, 1)
+		assert.Len(t, emitting.emitted, 1)
+		assert.Equal(t, []int{1}, codes)
+	})
+
+	t.Run("should panic with the message after delivering panic messages", func(t *testing.T) {
+		emitting := &terminatingRecorder{}
+
+		logger := New(WithTarget(emitting, levels.Info))
+
+		assert.PanicsWithValue(t, "panic message", func() {
+			logger.Log(levels.Panic, nil, nil, "panic %s", "message")
+		})
+
+		assert.Len(t, emitting.emitted, 1)
+	})
+
+	t.Run("should skip disabled targets", func(t *testing.T) {
+		disabled := &recorder{}
+
+		logger := New(WithTarget(disabled, levels.Disabled))
+
+		assert.Panics(t, func() {
+			logger.Log(levels.Panic, nil, nil, "panic message")
+		})
+
+		assert.Empty(t, disabled.records)
+	})
+
+	t.Run("should not log disabled level", func(t *testing.T) {
+		debug := &recorder{}
+
+		logger := New(WithTarget(debug, levels.Debug))
+
+		logger.Log(levels.Disabled, nil, nil, "disabled message")
+
+		assert.Empty(t, debug.records)
+	})
+
+	t.Run("should deliver to all targets when one panics", func(t *testing.T) {
+		first := &recorder{panics: true}
+		second := &recorder{}
+
+		logger := New(WithTarget(first, levels.Debug), WithTarget(second, levels.Debug))
+
+		assert.PanicsWithValue(t, "recorder panic", func() {
+			logger.Log(levels.Panic, errors.New("test error"), nil, "panic message")
+		})
+
+		assert.Len(t, first.records, 1)
+		assert.Len(t, second.records, 1)
+	})
+}
+
+func TestAdapter_LogAt(t *testing.T) {
+	timed := &timedRecorder{}
+	plain := &recorder{}
+
+	logger := New(WithTarget(timed, levels.Debug), WithTarget(plain, levels.Debug))
+
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger.LogAt(ts, levels.Info, nil, nil, "info message")
+
+	assert.Equal(t, []time.Time{ts}, timed.times)
+	assert.Len(t, timed.records, 1)
+	assert.Len(t, plain.records, 1)
+}
+
+func TestAdapter_Level(t *testing.T) {
+	t.Run("should return lowest target level", func(t *testing.T) {
+		logger := New(WithTarget(&recorder{}, levels.Error), WithTarget(&recorder{}, levels.Info))
+
+		assert.Equal(t, levels.Info, logger.Level())
+	})
+
+	t.Run("should return disabled with no targets", func(t *testing.T) {
+		assert.Equal(t, levels.Disabled, New().Level())
+	})
+
+	t.Run("should change all target levels", func(t *testing.T) {
+		info := &recorder{}
+		errs := &recorder{}
+
+		logger := New(WithTarget(info, levels.Info), WithTarget(errs, levels.Error))
+		logger.SetLevel(levels.Warn)
+
+		logger.Log(levels.Warn, nil, nil, "warn message")
+
+		assert.Equal(t, levels.Warn, logger.Level())
+		assert.Equal(t, levels.Warn, info.Level())
+		assert.Equal(t, levels.Warn, errs.Level())
+		assert.Len(t, info.records, 1)
+		assert.Len(t, errs.records, 1)
+	})
+}
+
+func TestAdapter_Writer(t *testing.T) {
+	t.Run("should write to all targets", func(t *testing.T) {
+		var out1, out2 bytes.Buffer
+
+		logger := New(
+			WithTarget(&recorder{writer: &out1}, levels.Info),
+			WithTarget(&recorder{writer: &out2}, levels.Info),
+		)
+
+		n, err := logger.Writer().Write([]byte("message"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, n)
+		assert.Equal(t, "message", out1.String())
+		assert.Equal(t, "message", out2.String())
+	})
+
+	t.Run("should continue writing when a target fails", func(t *testing.T) {
+		var out bytes.Buffer
+
+		logger := New(
+			WithTarget(&recorder{writer: failWriter{}}, levels.Info),
+			WithTarget(&recorder{writer: &out}, levels.Info),
+		)
+
+		_, err := logger.Writer().Write([]byte("message"))
+
+		assert.Error(t, err)
+		assert.Equal(t, "message", out.String())
+	})
+
+	t.Run("should change all target writers", func(t *testing.T) {
+		var out bytes.Buffer
+
+		first := &recorder{}
+		second := &recorder{}
+
+		logger := New(WithTarget(first, levels.Info), WithTarget(second, levels.Info))
+		logger.SetWriter(&out)
+
+		assert.Same(t, &out, first.Writer())
+		assert.Same(t, &out, second.Writer())
+		assert.Same(t, &out, logger.Writer())
+	})
+}
+
+func TestAdapter_Emit(t *testing.T) {
+	emitting := &terminatingRecorder{recorder: recorder{panics: true}}
+	plain := &recorder{}
+
+	logger := New(WithTarget(emitting, levels.Info), WithTarget(plain, levels.Info))
+
+	logger.Emit(time.Now(), levels.Fatal, nil, nil, "Test message")
+
+	assert.Equal(t, []record{{level: levels.Fatal, msg: "Test message"}}, emitting.emitted)
+	assert.Empty(t, emitting.records, "targets with Emit must not be written with Log")
+	assert.Equal(t, []record{{level: levels.Fatal, msg: "Test message"}}, plain.records)
+}
+
+func TestAdapter_SetExitFunc(t *testing.T) {
+	first := &terminatingRecorder{}
+	second := &terminatingRecorder{}
+
+	logger := New(
+		WithTarget(first, levels.Info),
+		WithTarget(&recorder{}, levels.Info),
+		WithTarget(second, levels.Error),
+	)
+
+	exitFunc := func(int) {}
+
+	logger.SetExitFunc(exitFunc)
+
+	assert.NotNil(t, first.exitFunc)
+	assert.NotNil(t, second.exitFunc)
+}
+
+func TestAdapter_Flush(t *testing.T) {
+	flushErr := errors.New("flush error")
+
+	first := &terminatingRecorder{flushErr: flushErr}
+	second := &terminatingRecorder{}
+
+	logger := New(
+		WithTarget(first, levels.Info),
+		WithTarget(&recorder{}, levels.Info),
+		WithTarget(second, levels.Error),
+	)
+
+	assert.ErrorIs(t, logger.Flush(context.Background()), flushErr)
+	assert.Equal(t, 1, first.flushed)
+	assert.Equal(t, 1, second.flushed)
+}
+
+func TestAdapter_ConcurrentLog(_ *testing.T) {
+	logger := New(WithTarget(&recorder{}, levels.Debug))
+
+	const goroutines = 100
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			logger.Log(levels.Info, nil, nil, "message")
+			logger.SetLevel(levels.Debug)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestAdapter_Conformance(t *testing.T) {
+	adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+		target := native.New(
+			native.WithWriter(config.Writer),
+			native.WithLevel(config.Level),
+			native.WithExitFunc(config.ExitFunc),
+		)
+
+		return New(WithTarget(target, config.Level), WithExitFunc(config.ExitFunc))
+	})
+}
@@ -0,0 +1,36 @@
+package multi
+
+import (
+	"github.com/danteay/golog/levels"
+)
+
+type target struct {
+	adapter Target
+	level   levels.Level
+}
+
+type options struct {
+	targets  []target
+	exitFunc func(code int)
+}
+
+// Option defines the signature for the options.
+type Option func(*options)
+
+// WithTarget adds an adapter that receives the log messages with a level equal or above the provided one.
+func WithTarget(adapter Target, level levels.Level) Option {
+	return func(opts *options) {
+		if adapter == nil {
+			return
+		}
+
+		opts.targets = append(opts.targets, target{adapter: adapter, level: level})
+	}
+}
+
+// WithExitFunc sets the function called with the exit code after writing Fatal messages. Defaults to os.Exit.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(opts *options) {
+		opts.exitFunc = exitFunc
+	}
+}
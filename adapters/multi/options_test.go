@@ -0,0 +1,37 @@
+package multi
+
+import (
+	"testing"
+
+	"github.com/danteay/golog/levels"
+)
+
+func TestWithTarget(t *testing.T) {
+	opts := &options{}
+	adapter := &recorder{}
+
+	WithTarget(adapter, levels.Warn)(opts)
+	WithTarget(nil, levels.Info)(opts)
+
+	if len(opts.targets) != 1 {
+		t.Fatalf("Expected 1 target, but got %d", len(opts.targets))
+	}
+
+	if opts.targets[0].adapter != adapter {
+		t.Error("Expected target adapter to be the provided adapter, but it's not")
+	}
+
+	if opts.targets[0].level != levels.Warn {
+		t.Errorf("Expected level to be Warn, but got %v", opts.targets[0].level)
+	}
+}
+
+func TestWithExitFunc(t *testing.T) {
+	opts := &options{}
+
+	WithExitFunc(func(int) {})(opts)
+
+	if opts.exitFunc == nil {
+		t.Error("Expected exit function to be set, but it's nil")
+	}
+}
@@ -0,0 +1,423 @@
+package native
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// encoderConfig holds the encoding settings of the adapter, with the level values resolved once so they are not
+// built on every message.
+type encoderConfig struct {
+	logencoding.Config
+	levelNames [levels.Panic + 1]string
+	quoteTime  bool
+}
+
+func newEncoderConfig(config logencoding.Config) *encoderConfig {
+	c := &encoderConfig{Config: config}
+
+	for level := levels.TraceLevel; level <= levels.Panic; level++ {
+		c.levelNames[level] = config.Level(level)
+	}
+
+	c.quoteTime = !config.IsNumericTime() && strings.ContainsAny(config.TimeFormat, " \"=")
+
+	return c
+}
+
+// levelName returns the level value with the configured casing.
+func (c *encoderConfig) levelName(level levels.Level) string {
+	if level < levels.TraceLevel || level > levels.Panic {
+		return ""
+	}
+
+	return c.levelNames[level]
+}
+
+func encodeJSON(buf []byte, e *entry, c *encoderConfig) []byte {
+	buf = append(buf, '{')
+	buf = appendJSONString(buf, c.TimeKey)
+	buf = append(buf, ':')
+
+	if c.IsNumericTime() {
+		buf = c.AppendTime(buf, e.time)
+	} else {
+		buf = append(buf, '"')
+		buf = c.AppendTime(buf, e.time)
+		buf = append(buf, '"')
+	}
+
+	buf = append(buf, ',')
+	buf = appendJSONString(buf, c.LevelKey)
+	buf = append(buf, ':')
+	buf = appendJSONString(buf, c.levelName(e.level))
+	buf = append(buf, ',')
+	buf = appendJSONString(buf, c.MessageKey)
+	buf = append(buf, ':')
+	buf = appendJSONString(buf, e.message)
+
+	if e.err != nil {
+		buf = append(buf, ',')
+		buf = appendJSONString(buf, c.ErrorKey)
+		buf = append(buf, ':')
+		buf = appendJSONString(buf, e.err.Error())
+	}
+
+	if e.stack != nil {
+		buf = append(buf, ',')
+		buf = appendJSONString(buf, c.StackKey)
+		buf = append(buf, ':')
+		buf = appendJSONValue(buf, e.stack)
+	}
+
+	for _, f := range e.fields {
+		buf = append(buf, ',')
+		buf = appendJSONString(buf, f.Key)
+		buf = append(buf, ':')
+		buf = appendJSONField(buf, f)
+	}
+
+	return append(buf, '}', '\n')
+}
+
+func encodeLogfmt(buf []byte, e *entry, c *encoderConfig) []byte {
+	buf = logencoding.AppendLogfmtKey(buf, c.TimeKey)
+	buf = append(buf, '=')
+
+	if c.quoteTime {
+		buf = append(buf, '"')
+		buf = c.AppendTime(buf, e.time)
+		buf = append(buf, '"')
+	} else {
+		buf = c.AppendTime(buf, e.time)
+	}
+
+	buf = append(buf, ' ')
+	buf = logencoding.AppendLogfmtKey(buf, c.LevelKey)
+	buf = append(buf, '=')
+	buf = logencoding.AppendLogfmtValue(buf, c.levelName(e.level))
+	buf = append(buf, ' ')
+	buf = logencoding.AppendLogfmtKey(buf, c.MessageKey)
+	buf = append(buf, '=')
+	buf = logencoding.AppendLogfmtValue(buf, e.message)
+
+	if e.err != nil {
+		buf = append(buf, ' ')
+		buf = logencoding.AppendLogfmtKey(buf, c.ErrorKey)
+		buf = append(buf, '=')
+		buf = logencoding.AppendLogfmtValue(buf, e.err.Error())
+	}
+
+	if e.stack != nil {
+		buf = append(buf, ' ')
+		buf = logencoding.AppendLogfmtKey(buf, c.StackKey)
+		buf = append(buf, '=')
+		buf = appendLogfmtValue(buf, e.stack)
+	}
+
+	for _, f := range e.fields {
+		buf = append(buf, ' ')
+		buf = logencoding.AppendLogfmtKey(buf, f.Key)
+		buf = append(buf, '=')
+		buf = appendLogfmtField(buf, f)
+	}
+
+	return append(buf, '\n')
+}
+
+func encodeConsole(buf []byte, e *entry, c *encoderConfig) []byte {
+	return appendConsole(buf, e, c, false)
+}
+
+func encodeColoredConsole(buf []byte, e *entry, c *encoderConfig) []byte {
+	return appendConsole(buf, e, c, true)
+}
+
+// appendConsole encodes the entry as "<time> <LVL> <message> key=value...", with the stack trace frames on the
+// following lines. The time and level keep the console layout, so only the error key is taken from the config.
+func appendConsole(buf []byte, e *entry, c *encoderConfig, colored bool) []byte {
+	t := e.time
+	if c.UTC {
+		t = t.UTC()
+	}
+
+	buf = appendColored(buf, colored, logencoding.ColorGray, func(buf []byte) []byte {
+		return t.AppendFormat(buf, logencoding.ConsoleTimeFormat)
+	})
+
+	buf = append(buf, ' ')
+	buf = appendColored(buf, colored, logencoding.LevelColor(e.level), func(buf []byte) []byte {
+		return append(buf, logencoding.LevelAbbreviation(e.level)...)
+	})
+
+	buf = append(buf, ' ')
+	buf = append(buf, e.message...)
+
+	if e.err != nil {
+		buf = append(buf, ' ')
+		buf = appendColored(buf, colored, logencoding.ColorRed, func(buf []byte) []byte {
+			buf = logencoding.AppendLogfmtKey(buf, c.ErrorKey)
+			return append(buf, '=')
+		})
+		buf = logencoding.AppendLogfmtValue(buf, e.err.Error())
+	}
+
+	for _, f := range e.fields {
+		buf = append(buf, ' ')
+		buf = appendColored(buf, colored, logencoding.ColorGray, func(buf []byte) []byte {
+			buf = logencoding.AppendLogfmtKey(buf, f.Key)
+			return append(buf, '=')
+		})
+		buf = appendLogfmtField(buf, f)
+	}
+
+	for _, frame := range e.stack {
+		if frame == "" {
+			continue
+		}
+
+		buf = append(buf, "\n    "...)
+		buf = append(buf, frame...)
+	}
+
+	return append(buf, '\n')
+}
+
+func appendColored(buf []byte, colored bool, color string, appendFn func([]byte) []byte) []byte {
+	if !colored {
+		return appendFn(buf)
+	}
+
+	buf = append(buf, color...)
+	buf = appendFn(buf)
+
+	return append(buf, logencoding.ColorReset...)
+}
+
+// appendJSONString appends s as a quoted JSON string, escaping the characters that are not valid inside it and
+// replacing invalid UTF-8 sequences.
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+
+	start := 0
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				buf = append(buf, s[start:i]...)
+				buf = append(buf, "\ufffd"...)
+				i += size
+				start = i
+
+				continue
+			}
+
+			i += size
+
+			continue
+		}
+
+		if c >= 0x20 && c != '"' && c != '\\' {
+			i++
+			continue
+		}
+
+		buf = append(buf, s[start:i]...)
+
+		switch c {
+		case '"', '\\':
+			buf = append(buf, '\\', c)
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		default:
+			buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+		}
+
+		i++
+		start = i
+	}
+
+	buf = append(buf, s[start:]...)
+
+	return append(buf, '"')
+}
+
+// appendJSONField appends the field value with the encoder of its kind, or as any other value when it has no typed
+// representation.
+func appendJSONField(buf []byte, f fields.Field) []byte {
+	switch f.Kind() {
+	case fields.KindString:
+		return appendJSONString(buf, f.StringValue())
+	case fields.KindInt64:
+		return strconv.AppendInt(buf, f.Int64Value(), 10)
+	case fields.KindUint64:
+		return strconv.AppendUint(buf, f.Uint64Value(), 10)
+	case fields.KindFloat64:
+		return appendJSONFloat(buf, f.Float64Value(), 64)
+	case fields.KindBool:
+		return strconv.AppendBool(buf, f.BoolValue())
+	case fields.KindDuration:
+		return appendJSONString(buf, f.DurationValue().String())
+	case fields.KindStringer:
+		return appendJSONString(buf, f.StringerValue().String())
+	case fields.KindBytes:
+		return appendJSONString(buf, string(f.BytesValue()))
+	default:
+		return appendJSONValue(buf, f.Value())
+	}
+}
+
+func appendJSONValue(buf []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case string:
+		return appendJSONString(buf, v)
+	case bool:
+		return strconv.AppendBool(buf, v)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(buf, v, 10)
+	case float32:
+		return appendJSONFloat(buf, float64(v), 32)
+	case float64:
+		return appendJSONFloat(buf, v, 64)
+	case time.Time:
+		buf = append(buf, '"')
+		buf = v.AppendFormat(buf, time.RFC3339Nano)
+
+		return append(buf, '"')
+	case time.Duration:
+		return appendJSONString(buf, v.String())
+	case error:
+		return appendJSONString(buf, v.Error())
+	case []string:
+		buf = append(buf, '[')
+
+		for i, s := range v {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+
+			buf = appendJSONString(buf, s)
+		}
+
+		return append(buf, ']')
+	case json.Marshaler:
+		return appendMarshaled(buf, v)
+	case fmt.Stringer:
+		return appendJSONString(buf, v.String())
+	default:
+		return appendMarshaled(buf, v)
+	}
+}
+
+// appendJSONFloat appends the float as a JSON number, or as a string for the values JSON can't represent.
+func appendJSONFloat(buf []byte, f float64, bitSize int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		buf = append(buf, '"')
+		buf = strconv.AppendFloat(buf, f, 'g', -1, bitSize)
+
+		return append(buf, '"')
+	}
+
+	return strconv.AppendFloat(buf, f, 'g', -1, bitSize)
+}
+
+func appendMarshaled(buf []byte, value any) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return appendJSONString(buf, fmt.Sprint(value))
+	}
+
+	return append(buf, data...)
+}
+
+// appendLogfmtField appends the field value with the encoder of its kind, or as any other value when it has no typed
+// representation.
+func appendLogfmtField(buf []byte, f fields.Field) []byte {
+	switch f.Kind() {
+	case fields.KindString:
+		return logencoding.AppendLogfmtValue(buf, f.StringValue())
+	case fields.KindInt64:
+		return strconv.AppendInt(buf, f.Int64Value(), 10)
+	case fields.KindUint64:
+		return strconv.AppendUint(buf, f.Uint64Value(), 10)
+	case fields.KindFloat64:
+		return strconv.AppendFloat(buf, f.Float64Value(), 'g', -1, 64)
+	case fields.KindBool:
+		return strconv.AppendBool(buf, f.BoolValue())
+	case fields.KindDuration:
+		return append(buf, f.DurationValue().String()...)
+	case fields.KindStringer:
+		return logencoding.AppendLogfmtValue(buf, f.StringerValue().String())
+	case fields.KindBytes:
+		return logencoding.AppendLogfmtValue(buf, string(f.BytesValue()))
+	default:
+		return appendLogfmtValue(buf, f.Value())
+	}
+}
+
+func appendLogfmtValue(buf []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case string:
+		return logencoding.AppendLogfmtValue(buf, v)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return appendJSONValue(buf, v)
+	case float32:
+		return strconv.AppendFloat(buf, float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(buf, v, 'g', -1, 64)
+	case time.Time:
+		return v.AppendFormat(buf, time.RFC3339Nano)
+	case time.Duration:
+		return append(buf, v.String()...)
+	case error:
+		return logencoding.AppendLogfmtValue(buf, v.Error())
+	case fmt.Stringer:
+		return logencoding.AppendLogfmtValue(buf, v.String())
+	default:
+		start := len(buf)
+		buf = appendMarshaled(buf, v)
+		encoded := string(buf[start:])
+
+		return logencoding.AppendLogfmtValue(buf[:start], encoded)
+	}
+}
@@ -0,0 +1,74 @@
+package native
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendJSONString(t *testing.T) {
+	tests := map[string]string{
+		"plain":             `"plain"`,
+		`quote " and \`:     `"quote \" and \\"`,
+		"new\nline\ttab\r":  `"new\nline\ttab\r"`,
+		"control \x01":      `"control \u0001"`,
+		"unicode ñ 😀":       `"unicode ñ 😀"`,
+		"invalid \xff utf8": "\"invalid � utf8\"",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, string(appendJSONString(nil, input)), input)
+	}
+}
+
+func TestAppendJSONValue(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{value: nil, expected: `null`},
+		{value: "value", expected: `"value"`},
+		{value: true, expected: `true`},
+		{value: -42, expected: `-42`},
+		{value: uint8(42), expected: `42`},
+		{value: 1.5, expected: `1.5`},
+		{value: float32(0.25), expected: `0.25`},
+		{value: math.Inf(1), expected: `"+Inf"`},
+		{value: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), expected: `"2020-01-01T00:00:00Z"`},
+		{value: 2 * time.Second, expected: `"2s"`},
+		{value: errors.New("some error"), expected: `"some error"`},
+		{value: []string{"a", "b"}, expected: `["a","b"]`},
+		{value: map[string]any{"key": 1}, expected: `{"key":1}`},
+		{value: struct{ Key string }{Key: "value"}, expected: `{"Key":"value"}`},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, string(appendJSONValue(nil, test.value)))
+	}
+}
+
+func TestAppendLogfmtValue(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{value: nil, expected: `null`},
+		{value: "value", expected: `value`},
+		{value: "", expected: `""`},
+		{value: "some value", expected: `"some value"`},
+		{value: "key=value", expected: `"key=value"`},
+		{value: `"quoted"`, expected: `"\"quoted\""`},
+		{value: 42, expected: `42`},
+		{value: 1.5, expected: `1.5`},
+		{value: 2 * time.Second, expected: `2s`},
+		{value: map[string]any{"key": "some value"}, expected: `"{\"key\":\"some value\"}"`},
+		{value: []int{1, 2}, expected: `[1,2]`},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, string(appendLogfmtValue(nil, test.value)))
+	}
+}
@@ -0,0 +1,240 @@
+package native
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+// Adapter is an adapter implementation with its own encoders, that has no dependencies apart from the standard
+// library. Messages are encoded on pooled buffers and the fields are read without copying them, so logging a message
+// with primitive fields does not allocate.
+type Adapter struct {
+	mutex     *sync.Mutex
+	level     *atomic.Int32
+	writer    io.Writer
+	encode    encodeFunc
+	config    *encoderConfig
+	exitFunc  func(code int)
+	withTrace bool
+}
+
+type encodeFunc func(buf []byte, e *entry, c *encoderConfig) []byte
+
+// entry holds the data of a message while it is encoded.
+type entry struct {
+	time    time.Time
+	level   levels.Level
+	err     error
+	message string
+	stack   []string
+	fields  []fields.Field
+}
+
+type buffer struct {
+	bytes []byte
+	entry entry
+}
+
+const (
+	// maxPooledSize is the maximum capacity in bytes of the buffers returned to the pool, so a single big message does
+	// not keep its memory for the rest of the program.
+	maxPooledSize = 64 * 1024
+
+	// maxPooledFields is the maximum capacity of the field lists returned to the pool, for the same reason.
+	maxPooledFields = 256
+)
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return &buffer{bytes: make([]byte, 0, 1024), entry: entry{fields: make([]fields.Field, 0, 16)}}
+	},
+}
+
+func New(opts ...Option) *Adapter {
+	logOpts := options{
+		level:  levels.Info,
+		writer: os.Stdout,
+		format: JSON,
+		config: logencoding.New(),
+	}
+
+	for _, opt := range opts {
+		opt(&logOpts)
+	}
+
+	if logOpts.exitFunc == nil {
+		logOpts.exitFunc = os.Exit
+	}
+
+	level := &atomic.Int32{}
+	level.Store(int32(logOpts.level))
+
+	return &Adapter{
+		mutex:     &sync.Mutex{},
+		level:     level,
+		writer:    logOpts.writer,
+		encode:    getEncoder(logOpts.format, logOpts.colored),
+		config:    newEncoderConfig(logOpts.config),
+		exitFunc:  logOpts.exitFunc,
+		withTrace: logOpts.withTrace,
+	}
+}
+
+// Writer returns the writer for the adapter
+func (a *Adapter) Writer() io.Writer {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.writer
+}
+
+// SetWriter sets the writer for the adapter
+func (a *Adapter) SetWriter(w io.Writer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.writer = w
+}
+
+// Level returns the level for the adapter
+func (a *Adapter) Level() levels.Level {
+	return levels.Level(a.level.Load())
+}
+
+// SetLevel sets the level for the adapter
+func (a *Adapter) SetLevel(level levels.Level) {
+	a.level.Store(int32(level))
+}
+
+// SetExitFunc sets the function called with the exit code after writing Fatal messages.
+func (a *Adapter) SetExitFunc(exitFunc func(code int)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.exitFunc = exitFunc
+}
+
+// Log logs a message with the given level, error, fields, and message
+func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt logs a message with the given timestamp, level, error, fields, and message. Fatal messages exit the program
+// and Panic messages panic after being written, with the error when it is set and with the message otherwise.
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	message := a.emit(t, level, err, logFields, msg, args)
+
+	switch {
+	case level == levels.Fatal:
+		a.mutex.Lock()
+		exitFunc := a.exitFunc
+		a.mutex.Unlock()
+
+		exitFunc(1)
+	case level == levels.Panic && err != nil:
+		panic(err)
+	case level == levels.Panic:
+		panic(message)
+	}
+}
+
+// Emit writes a message with the given timestamp, level, error, fields, and message, without exiting the program
+// after Fatal messages or panicking after Panic messages, so the caller can terminate on its own.
+func (a *Adapter) Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	a.emit(t, level, err, logFields, msg, args)
+}
+
+func (a *Adapter) emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args []any) string {
+	message := formatMessage(msg, args)
+
+	if adapterLevel := a.Level(); adapterLevel != levels.Disabled && level >= adapterLevel {
+		a.write(t, level, err, logFields, message)
+	}
+
+	return message
+}
+
+func (a *Adapter) write(t time.Time, level levels.Level, err error, logFields *fields.Fields, message string) {
+	buf, _ := bufferPool.Get().(*buffer)
+	defer putBuffer(buf)
+
+	buf.entry.time = t
+	buf.entry.level = level
+	buf.entry.err = err
+	buf.entry.message = message
+
+	withStack := err != nil && (a.withTrace || level == levels.TraceLevel)
+	if withStack && !logencoding.HasStack(logFields, a.config.StackKey, logencoding.DefaultStackKey) {
+		buf.entry.stack = logencoding.StackTrace()
+	}
+
+	if logFields != nil {
+		logFields.RangeFields(func(f fields.Field) bool {
+			buf.entry.fields = append(buf.entry.fields, f)
+			return true
+		})
+	}
+
+	buf.bytes = a.encode(buf.bytes[:0], &buf.entry, a.config)
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	_, _ = a.writer.Write(buf.bytes)
+}
+
+func putBuffer(buf *buffer) {
+	if cap(buf.bytes) > maxPooledSize || cap(buf.entry.fields) > maxPooledFields {
+		return
+	}
+
+	clear(buf.entry.fields)
+
+	buf.entry = entry{fields: buf.entry.fields[:0]}
+	bufferPool.Put(buf)
+}
+
+// formatMessage formats the message with the given arguments, skipping the formatting when it is not needed, e.g.
+// for the "%s" format used by golog.Logger.
+func formatMessage(msg string, args []any) string {
+	if len(args) == 0 {
+		return msg
+	}
+
+	if s, ok := args[0].(string); ok && len(args) == 1 && msg == "%s" {
+		return s
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
+
+func getEncoder(format Format, colored bool) encodeFunc {
+	switch format {
+	case Logfmt:
+		return encodeLogfmt
+	case Console:
+		if colored {
+			return encodeColoredConsole
+		}
+
+		return encodeConsole
+	default:
+		return encodeJSON
+	}
+}
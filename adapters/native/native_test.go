@@ -0,0 +1,479 @@
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adaptertest"
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+type testMsg struct {
+	Level   string   `json:"level"`
+	Message string   `json:"message"`
+	Error   string   `json:"error"`
+	Key1    string   `json:"key1"`
+	Key2    int      `json:"key2"`
+	Stack   []string `json:"stack"`
+}
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should log message", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		logFields := fields.New().SetMap(map[string]any{
+			"key1": "value1",
+			"key2": 42,
+		})
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.Debug, err, logFields, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, logFields.Get("key1"), res.Key1)
+		assert.Equal(t, logFields.Get("key2"), res.Key2)
+		assert.Equal(t, err.Error(), res.Error)
+	})
+
+	t.Run("should log message with no fields", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.Debug, err, nil, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, err.Error(), res.Error)
+	})
+
+	t.Run("should log message with no error", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		logFields := fields.New().SetMap(map[string]any{
+			"key1": "value1",
+			"key2": 42,
+		})
+
+		msg := "Test message"
+
+		logger.Log(levels.Debug, nil, logFields, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, logFields.Get("key1"), res.Key1)
+		assert.Equal(t, logFields.Get("key2"), res.Key2)
+		assert.Equal(t, "", res.Error)
+	})
+
+	t.Run("should log message with no fields and no error", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		msg := "Test message"
+
+		logger.Log(levels.Debug, nil, nil, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.Debug.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, "", res.Error)
+	})
+
+	t.Run("should not log message under level", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Info), WithWriter(&logOutput))
+
+		logFields := fields.New().SetMap(map[string]any{
+			"key1": "value1",
+			"key2": 42,
+		})
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.Debug, err, logFields, msg)
+
+		assert.Equal(t, "", logOutput.String())
+	})
+
+	t.Run("should log message with error and stack trace", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.TraceLevel), WithWriter(&logOutput))
+
+		msg := "Test message"
+		err := errors.New("test error")
+
+		logger.Log(levels.TraceLevel, err, nil, msg)
+
+		res := testMsg{}
+
+		if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+			t.Fatal(errUnmarshall)
+		}
+
+		assert.Equal(t, levels.TraceLevel.String(), res.Level)
+		assert.Equal(t, msg, res.Message)
+		assert.Equal(t, err.Error(), res.Error)
+		assert.NotEmpty(t, res.Stack)
+	})
+
+	t.Run("should change level", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Info), WithWriter(&logOutput))
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.Equal(t, "", logOutput.String())
+
+		logger.SetLevel(levels.Debug)
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.NotEqual(t, "", logOutput.String())
+	})
+
+	t.Run("should change writer", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.NotEqual(t, "", logOutput.String())
+
+		logOutput.Reset()
+
+		var newOutput bytes.Buffer
+
+		logger.SetWriter(&newOutput)
+
+		logger.Log(levels.Debug, nil, nil, "Test message")
+
+		assert.NotEqual(t, "", newOutput.String())
+		assert.Equal(t, "", logOutput.String())
+	})
+}
+
+func TestAdapter_LogAt(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger.LogAt(ts, levels.Info, nil, fields.New().Set("key1", "value1"), "Test %s", "message")
+
+	res := struct {
+		testMsg
+		Time time.Time `json:"time"`
+	}{}
+
+	if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+		t.Fatal(errUnmarshall)
+	}
+
+	assert.True(t, ts.Equal(res.Time))
+	assert.Equal(t, "Test message", res.Message)
+	assert.Equal(t, "value1", res.Key1)
+}
+
+func TestAdapter_Formats(t *testing.T) {
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	logFields := fields.New().Set("key2", 42).Set("key1", "some value").Set("key3", true)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "should encode json",
+			opts:     []Option{WithFormat(JSON)},
+			expected: `{"time":"2020-01-01T00:00:00Z","level":"warn","message":"Test message","error":"test error","key2":42,"key1":"some value","key3":true}` + "\n",
+		},
+		{
+			name:     "should encode logfmt",
+			opts:     []Option{WithFormat(Logfmt)},
+			expected: `time=2020-01-01T00:00:00Z level=warn message="Test message" error="test error" key2=42 key1="some value" key3=true` + "\n",
+		},
+		{
+			name:     "should encode console",
+			opts:     []Option{WithFormat(Console)},
+			expected: `2020-01-01 00:00:00.000 WRN Test message error="test error" key2=42 key1="some value" key3=true` + "\n",
+		},
+		{
+			name: "should encode colored console",
+			opts: []Option{Colored()},
+			expected: "\x1b[90m2020-01-01 00:00:00.000\x1b[0m \x1b[33mWRN\x1b[0m Test message \x1b[31merror=\x1b[0m\"test error\" " +
+				"\x1b[90mkey2=\x1b[0m42 \x1b[90mkey1=\x1b[0m\"some value\" \x1b[90mkey3=\x1b[0mtrue\n",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var logOutput bytes.Buffer
+
+			logger := New(append(test.opts, WithWriter(&logOutput))...)
+
+			logger.LogAt(ts, levels.Warn, errors.New("test error"), logFields, "Test %s", "message")
+
+			assert.Equal(t, test.expected, logOutput.String())
+		})
+	}
+}
+
+func TestAdapter_Encoding(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.FixedZone("CST", -6*60*60))
+	logFields := fields.New().Set("user", "john")
+
+	tests := []struct {
+		name     string
+		opts     []logencoding.Option
+		level    levels.Level
+		err      error
+		expected map[string]any
+	}{
+		{
+			name:  "should use the encoding defaults",
+			level: levels.Info,
+			expected: map[string]any{
+				"time":    "2024-01-02T03:04:05.006-06:00",
+				"level":   "info",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+		{
+			name: "should use custom keys, upper case levels and unix times",
+			opts: []logencoding.Option{
+				logencoding.WithTimeKey("ts"),
+				logencoding.WithLevelKey("severity"),
+				logencoding.WithMessageKey("msg"),
+				logencoding.WithErrorKey("err"),
+				logencoding.WithLevelCase(logencoding.Uppercase),
+				logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+			},
+			level: levels.Warn,
+			err:   errors.New("test error"),
+			expected: map[string]any{
+				"ts":       float64(ts.UnixMilli()),
+				"severity": "WARN",
+				"msg":      "Test message",
+				"err":      "test error",
+				"user":     "john",
+			},
+		},
+		{
+			name:  "should use a custom layout on UTC",
+			opts:  []logencoding.Option{logencoding.WithTimeFormat(time.RFC3339), logencoding.WithUTC()},
+			level: levels.Error,
+			expected: map[string]any{
+				"time":    "2024-01-02T09:04:05Z",
+				"level":   "error",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var logOutput bytes.Buffer
+
+			logger := New(WithWriter(&logOutput), WithEncoding(test.opts...))
+
+			logger.LogAt(ts, test.level, test.err, logFields, "Test %s", "message")
+
+			res := map[string]any{}
+
+			if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expected, res)
+		})
+	}
+
+	t.Run("should use the stack key", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(
+			WithLevel(levels.TraceLevel),
+			WithWriter(&logOutput),
+			WithEncoding(logencoding.WithStackKey("trace")),
+		)
+
+		logger.LogAt(ts, levels.TraceLevel, errors.New("test error"), nil, "Test message")
+
+		res := map[string]any{}
+
+		if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "trace", res["level"])
+		assert.NotEmpty(t, res["trace"])
+		assert.NotContains(t, res, "stack")
+	})
+}
+
+func TestAdapter_Panic(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithWriter(&logOutput))
+
+	assert.PanicsWithValue(t, "Test message", func() {
+		logger.Log(levels.Panic, nil, nil, "Test message")
+	})
+
+	assert.NotEqual(t, "", logOutput.String())
+}
+
+func BenchmarkAdapter_Log(b *testing.B) {
+	logFields := fields.New().SetMap(map[string]any{
+		"service":  "api",
+		"status":   200,
+		"duration": 12.5,
+		"cached":   false,
+		"user_id":  int64(42),
+	})
+
+	formats := map[string]Format{
+		"json":    JSON,
+		"logfmt":  Logfmt,
+		"console": Console,
+	}
+
+	for name, format := range formats {
+		logger := New(WithWriter(io.Discard), WithFormat(format))
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				logger.Log(levels.Info, nil, logFields, "request finished")
+			}
+		})
+	}
+}
+
+func TestAdapter_LogAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector drops pooled buffers at random")
+	}
+
+	logFields := fields.New().SetMap(map[string]any{
+		"service":  "api",
+		"status":   200,
+		"duration": 12.5,
+		"cached":   false,
+		"user_id":  int64(42),
+	})
+
+	for _, format := range []Format{JSON, Logfmt, Console} {
+		logger := New(WithWriter(io.Discard), WithFormat(format))
+
+		allocs := testing.AllocsPerRun(100, func() {
+			logger.Log(levels.Info, nil, logFields, "%s", "request finished")
+		})
+
+		assert.Zero(t, allocs)
+	}
+}
+
+func TestAdapter_SetExitFunc(t *testing.T) {
+	var (
+		logOutput bytes.Buffer
+		codes     []int
+	)
+
+	logger := New(WithWriter(&logOutput), WithExitFunc(func(int) {
+		t.Error("the replaced exit function was called")
+	}))
+
+	logger.SetExitFunc(func(code int) { codes = append(codes, code) })
+
+	logger.Log(levels.Fatal, nil, nil, "Test message")
+
+	assert.Equal(t, []int{1}, codes)
+	assert.Contains(t, logOutput.String(), "Test message")
+}
+
+func TestAdapter_Conformance(t *testing.T) {
+	t.Run("should pass with the default encoding", func(t *testing.T) {
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+			)
+		})
+	})
+
+	t.Run("should pass with a custom encoding", func(t *testing.T) {
+		encodingOpts := []logencoding.Option{
+			logencoding.WithMessageKey("msg"),
+			logencoding.WithLevelCase(logencoding.Uppercase),
+			logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+		}
+
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+				WithEncoding(encodingOpts...),
+			)
+		}, adaptertest.WithEncoding(logencoding.New(encodingOpts...)))
+	})
+}
@@ -0,0 +1,87 @@
+package native
+
+import (
+	"io"
+
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+// Format defines the encoding of the log messages.
+type Format int
+
+const (
+	// JSON encodes every message as a JSON object.
+	JSON Format = iota
+
+	// Logfmt encodes every message as a line of key=value pairs.
+	Logfmt
+
+	// Console encodes every message as a human-readable line, meant for local environments.
+	Console
+)
+
+type options struct {
+	level     levels.Level
+	writer    io.Writer
+	format    Format
+	colored   bool
+	withTrace bool
+	config    logencoding.Config
+	exitFunc  func(code int)
+}
+
+// Option defines the signature for the options.
+type Option func(*options)
+
+// WithLevel sets the log level for the logger.
+func WithLevel(level levels.Level) Option {
+	return func(opts *options) {
+		opts.level = level
+	}
+}
+
+// WithWriter sets the writer for the logger.
+func WithWriter(writer io.Writer) Option {
+	return func(opts *options) {
+		opts.writer = writer
+	}
+}
+
+// WithFormat sets the encoding of the log messages. Defaults to JSON.
+func WithFormat(format Format) Option {
+	return func(opts *options) {
+		opts.format = format
+	}
+}
+
+// Colored sets the logger to use the Console format with colored levels.
+func Colored() Option {
+	return func(opts *options) {
+		opts.format = Console
+		opts.colored = true
+	}
+}
+
+// WithTrace sets the error trace for the logger.
+func WithTrace() Option {
+	return func(opts *options) {
+		opts.withTrace = true
+	}
+}
+
+// WithEncoding sets the key names, level casing and time format of the messages, e.g.
+// WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs)). The settings that
+// are not provided take the encoding defaults.
+func WithEncoding(opts ...logencoding.Option) Option {
+	return func(o *options) {
+		o.config = logencoding.New(opts...)
+	}
+}
+
+// WithExitFunc sets the function called with the exit code after writing Fatal messages. Defaults to os.Exit.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(opts *options) {
+		opts.exitFunc = exitFunc
+	}
+}
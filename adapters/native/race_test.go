@@ -0,0 +1,6 @@
+//go:build race
+
+package native
+
+// raceEnabled reports whether the tests run with the race detector, that makes sync.Pool drop pooled values at random.
+const raceEnabled = true
@@ -0,0 +1,153 @@
+package slog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/danteay/golog/logencoding"
+)
+
+// consoleHandler is a slog.Handler that writes every record as a human-readable line with colored levels, with the
+// format "<time> <LVL> <message> key=value...". Groups are flattened with dot separated keys.
+type consoleHandler struct {
+	mutex  *sync.Mutex
+	writer io.Writer
+	level  slog.Leveler
+	attrs  []byte
+	prefix string
+}
+
+func newConsoleHandler(writer io.Writer, level slog.Leveler) *consoleHandler {
+	return &consoleHandler{
+		mutex:  &sync.Mutex{},
+		writer: writer,
+		level:  level,
+	}
+}
+
+// Enabled reports whether the handler writes records with the given level.
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes the record as a single line.
+func (h *consoleHandler) Handle(_ context.Context, record slog.Record) error {
+	buf := make([]byte, 0, 256)
+
+	if !record.Time.IsZero() {
+		buf = append(buf, logencoding.ColorGray...)
+		buf = record.Time.AppendFormat(buf, logencoding.ConsoleTimeFormat)
+		buf = append(buf, logencoding.ColorReset...)
+		buf = append(buf, ' ')
+	}
+
+	level := getGologLevel(record.Level)
+
+	buf = append(buf, logencoding.LevelColor(level)...)
+	buf = append(buf, logencoding.LevelAbbreviation(level)...)
+	buf = append(buf, logencoding.ColorReset...)
+	buf = append(buf, ' ')
+	buf = append(buf, record.Message...)
+	buf = append(buf, h.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		buf = appendConsoleAttr(buf, h.prefix, attr)
+		return true
+	})
+
+	buf = append(buf, '\n')
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	_, err := h.writer.Write(buf)
+
+	return err
+}
+
+// WithAttrs returns a new handler that writes the provided attributes on every record.
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	child := *h
+	child.attrs = append([]byte(nil), h.attrs...)
+
+	for _, attr := range attrs {
+		child.attrs = appendConsoleAttr(child.attrs, h.prefix, attr)
+	}
+
+	return &child
+}
+
+// WithGroup returns a new handler that writes the following attributes under the provided group.
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	child := *h
+	child.prefix += name + "."
+
+	return &child
+}
+
+func appendConsoleAttr(buf []byte, prefix string, attr slog.Attr) []byte {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return buf
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+
+		for _, groupAttr := range attr.Value.Group() {
+			buf = appendConsoleAttr(buf, prefix, groupAttr)
+		}
+
+		return buf
+	}
+
+	color := logencoding.ColorGray
+	if attr.Key == "error" && prefix == "" {
+		color = logencoding.ColorRed
+	}
+
+	buf = append(buf, ' ')
+	buf = append(buf, color...)
+	buf = logencoding.AppendLogfmtKey(buf, prefix+attr.Key)
+	buf = append(buf, '=')
+	buf = append(buf, logencoding.ColorReset...)
+
+	return appendConsoleValue(buf, attr.Value)
+}
+
+func appendConsoleValue(buf []byte, value slog.Value) []byte {
+	switch value.Kind() {
+	case slog.KindString:
+		return logencoding.AppendLogfmtValue(buf, value.String())
+	case slog.KindTime:
+		return value.Time().AppendFormat(buf, time.RFC3339Nano)
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			return logencoding.AppendLogfmtValue(buf, v.Error())
+		case fmt.Stringer:
+			return logencoding.AppendLogfmtValue(buf, v.String())
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return logencoding.AppendLogfmtValue(buf, fmt.Sprint(v))
+			}
+
+			return logencoding.AppendLogfmtValue(buf, string(data))
+		}
+	default:
+		return logencoding.AppendLogfmtValue(buf, value.String())
+	}
+}
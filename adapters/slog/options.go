@@ -4,12 +4,34 @@ import (
 	"io"
 
 	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+// Format defines the encoding of the log messages.
+type Format int
+
+const (
+	// JSON encodes every message as a JSON object, using the slog JSON handler.
+	JSON Format = iota
+
+	// Text encodes every message as a line of key=value pairs, using the slog text handler.
+	Text
+
+	// Logfmt encodes every message as a line of key=value pairs, replacing the characters that are not allowed on
+	// logfmt keys with underscores.
+	Logfmt
+
+	// Console encodes every message as a human-readable line with colored levels, meant for local environments.
+	Console
 )
 
 type options struct {
 	level     levels.Level
 	writer    io.Writer
+	format    Format
 	withTrace bool
+	config    logencoding.Config
+	exitFunc  func(code int)
 }
 
 // Option defines the signature for the options.
@@ -29,9 +51,34 @@ func WithWriter(writer io.Writer) Option {
 	}
 }
 
+// WithFormat sets the encoding of the log messages. Defaults to JSON. Nested fields are written as nested objects on
+// JSON, and flattened with dot separated keys on the rest of the formats, e.g. "user.id=42".
+func WithFormat(format Format) Option {
+	return func(opts *options) {
+		opts.format = format
+	}
+}
+
 // WithTrace sets the error trace for the logger.
 func WithTrace() Option {
 	return func(opts *options) {
 		opts.withTrace = true
 	}
 }
+
+// WithEncoding sets the key names, level casing and time format of the messages, e.g.
+// WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs)). The
+// settings that are not provided take the logencoding defaults, that are also used without this option. The Console
+// format keeps its human-readable layout, so only the error and stack keys are used on it.
+func WithEncoding(opts ...logencoding.Option) Option {
+	return func(o *options) {
+		o.config = logencoding.New(opts...)
+	}
+}
+
+// WithExitFunc sets the function called with the exit code after writing Fatal messages. Defaults to os.Exit.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(opts *options) {
+		opts.exitFunc = exitFunc
+	}
+}
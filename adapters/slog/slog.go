@@ -1,22 +1,29 @@
 package slog
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
-	"runtime/debug"
-	"strings"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/danteay/golog/fields"
 	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
 )
 
 // Adapter is a slog adapter implementation
 type Adapter struct {
+	mutex     *sync.RWMutex
 	logger    *slog.Logger
 	level     levels.Level
 	writer    io.Writer
+	format    Format
+	config    logencoding.Config
+	exitFunc  func(code int)
 	withTrace bool
 }
 
@@ -24,130 +31,306 @@ func New(opts ...Option) *Adapter {
 	logOpts := options{
 		level:  levels.Info,
 		writer: os.Stdout,
+		config: logencoding.New(),
 	}
 
 	for _, opt := range opts {
 		opt(&logOpts)
 	}
 
+	if logOpts.exitFunc == nil {
+		logOpts.exitFunc = os.Exit
+	}
+
 	adapter := &Adapter{
+		mutex:     &sync.RWMutex{},
 		writer:    logOpts.writer,
+		format:    logOpts.format,
+		config:    logOpts.config,
+		exitFunc:  logOpts.exitFunc,
 		withTrace: logOpts.withTrace,
 		level:     logOpts.level,
 	}
 
-	adapter.logger = getSlogInstance(adapter.level, adapter.writer)
+	adapter.logger = adapter.newLogger()
 
 	return adapter
 }
 
 func (a *Adapter) Writer() io.Writer {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
 	return a.writer
 }
 
 func (a *Adapter) SetWriter(w io.Writer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
 	a.writer = w
-	a.logger = getSlogInstance(a.level, a.writer)
+	a.logger = a.newLogger()
 }
 
 func (a *Adapter) Level() levels.Level {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
 	return a.level
 }
 
 func (a *Adapter) SetLevel(level levels.Level) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
 	a.level = level
-	a.logger = getSlogInstance(level, a.writer)
+	a.logger = a.newLogger()
+}
+
+// SetExitFunc sets the function called with the exit code after writing Fatal messages.
+func (a *Adapter) SetExitFunc(exitFunc func(code int)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.exitFunc = exitFunc
 }
 
 // Logger returns the slog logger instance
 func (a *Adapter) Logger() *slog.Logger {
-	return a.logger
+	logger, _ := a.state()
+	return logger
+}
+
+// state returns the slog logger and the level of the adapter, that are replaced together by SetWriter and SetLevel.
+func (a *Adapter) state() (*slog.Logger, levels.Level) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.logger, a.level
 }
 
 // Log logs a message with the given level, error, fields, and message
 func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt logs a message with the given timestamp, level, error, fields, and message. Fatal messages exit the program
+// and Panic messages panic after being written, with the error when it is set and with the message otherwise.
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	message := a.emit(t, level, err, logFields, msg, args)
+
+	switch {
+	case level == levels.Fatal:
+		a.mutex.RLock()
+		exitFunc := a.exitFunc
+		a.mutex.RUnlock()
+
+		exitFunc(1)
+	case level == levels.Panic && err != nil:
+		panic(err)
+	case level == levels.Panic:
+		panic(message)
+	}
+}
+
+// Emit writes a message with the given timestamp, level, error, fields, and message, without exiting the program
+// after Fatal messages or panicking after Panic messages, so the caller can terminate on its own.
+func (a *Adapter) Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
 	if level <= levels.Disabled {
 		return
 	}
 
+	a.emit(t, level, err, logFields, msg, args)
+}
+
+func (a *Adapter) emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args []any) string {
+	message := fmt.Sprintf(msg, args...)
+
+	if logger, adapterLevel := a.state(); adapterLevel != levels.Disabled {
+		a.write(logger, t, getLevels(level), message, a.getAttrs(level, err, logFields))
+	}
+
+	return message
+}
+
+func (a *Adapter) getAttrs(level levels.Level, err error, logFields *fields.Fields) []slog.Attr {
 	lenFields := 0
 	if logFields != nil {
 		lenFields = logFields.Len()
 	}
 
-	lf := make([]any, 0, lenFields)
+	lf := make([]slog.Attr, 0, lenFields)
 
 	if logFields != nil {
-		for key, val := range logFields.Data() {
-			lf = append(lf, slog.Any(key, val))
-		}
+		logFields.RangeFields(func(f fields.Field) bool {
+			lf = append(lf, getTypedAttr(f, a.format == Logfmt))
+			return true
+		})
 	}
 
-	lf = getErrFields(level, err, lf, a.withTrace)
-
-	msg = fmt.Sprintf(msg, args...)
-
-	switch level {
-	case levels.TraceLevel:
-		a.logger.Debug(msg, lf...)
-	case levels.Debug:
-		a.logger.Debug(msg, lf...)
-	case levels.Info:
-		a.logger.Info(msg, lf...)
-	case levels.Warn:
-		a.logger.Warn(msg, lf...)
-	case levels.Error:
-		a.logger.Error(msg, lf...)
-	case levels.Fatal:
-		a.logger.Error(msg, lf...)
-		os.Exit(1)
-	case levels.Panic:
-		a.logger.Error(msg, lf...)
-		panic(err)
-	default:
-		a.logger.Info(msg, lf...)
+	return a.getErrFields(level, err, logFields, lf)
+}
+
+func (a *Adapter) write(logger *slog.Logger, t time.Time, level slog.Level, msg string, attrs []slog.Attr) {
+	ctx := context.Background()
+	handler := logger.Handler()
+
+	if !handler.Enabled(ctx, level) {
+		return
 	}
+
+	record := slog.NewRecord(t, level, msg, 0)
+	record.AddAttrs(attrs...)
+
+	_ = handler.Handle(ctx, record)
 }
 
-func getErrFields(level levels.Level, err error, curFields []any, withTrace bool) []any {
+func (a *Adapter) getErrFields(level levels.Level, err error, logFields *fields.Fields, curFields []slog.Attr) []slog.Attr {
 	if err == nil {
 		return curFields
 	}
 
-	curFields = append(curFields, slog.Any("error", err))
+	curFields = append(curFields, slog.String(a.config.ErrorKey, err.Error()))
 
-	if level == levels.TraceLevel || withTrace {
-		curFields = append(curFields, slog.Any("stack", getStackTrace()))
+	withStack := level == levels.TraceLevel || a.withTrace
+	if withStack && !logencoding.HasStack(logFields, a.config.StackKey, logencoding.DefaultStackKey) {
+		curFields = append(curFields, slog.Any(a.config.StackKey, logencoding.StackTrace()))
 	}
 
 	return curFields
 }
 
-func getStackTrace() []string {
-	stack := strings.ReplaceAll(string(debug.Stack()), "\t", "")
-	return strings.Split(stack, "\n")
-}
+func (a *Adapter) newLogger() *slog.Logger {
+	handlerOpts := &slog.HandlerOptions{
+		AddSource:   false,
+		Level:       getLevels(a.level),
+		ReplaceAttr: replaceAttr(a.config),
+	}
+
+	var handler slog.Handler
 
-func getSlogInstance(level levels.Level, writer io.Writer) *slog.Logger {
-	handler := slog.NewJSONHandler(writer, &slog.HandlerOptions{
-		AddSource: false,
-		Level:     getLevels(level),
-	})
+	switch a.format {
+	case Text, Logfmt:
+		handler = slog.NewTextHandler(a.writer, handlerOpts)
+	case Console:
+		handler = newConsoleHandler(a.writer, handlerOpts.Level)
+	default:
+		handler = slog.NewJSONHandler(a.writer, handlerOpts)
+	}
 
 	return slog.New(handler)
 }
 
+// replaceAttr returns a slog.HandlerOptions.ReplaceAttr function that writes the time, level and message of the
+// records with the keys, level casing and time format of the config.
+func replaceAttr(config logencoding.Config) func(groups []string, attr slog.Attr) slog.Attr {
+	return func(groups []string, attr slog.Attr) slog.Attr {
+		if len(groups) > 0 {
+			return attr
+		}
+
+		switch attr.Key {
+		case slog.TimeKey:
+			if attr.Value.Kind() != slog.KindTime {
+				return attr
+			}
+
+			if config.IsNumericTime() {
+				return slog.Any(config.TimeKey, config.Time(attr.Value.Time()))
+			}
+
+			return slog.String(config.TimeKey, config.FormatTime(attr.Value.Time()))
+		case slog.LevelKey:
+			level, ok := attr.Value.Any().(slog.Level)
+			if !ok {
+				return attr
+			}
+
+			return slog.String(config.LevelKey, config.Level(getGologLevel(level)))
+		case slog.MessageKey:
+			return slog.Attr{Key: config.MessageKey, Value: attr.Value}
+		default:
+			return attr
+		}
+	}
+}
+
+// getAttr returns the attribute for the field, converting nested maps into groups so they are flattened with dot
+// separated keys by the text handlers. Keys are sanitized for the logfmt format.
+func getAttr(key string, value any, sanitize bool) slog.Attr {
+	if sanitize {
+		key = logencoding.LogfmtKey(key)
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok || len(nested) == 0 {
+		return slog.Any(key, value)
+	}
+
+	keys := make([]string, 0, len(nested))
+	for k := range nested {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	attrs := make([]any, 0, len(nested))
+	for _, k := range keys {
+		attrs = append(attrs, getAttr(k, nested[k], sanitize))
+	}
+
+	return slog.Group(key, attrs...)
+}
+
+// getTypedAttr returns the attribute of the field with the slog constructor of its kind, falling back to getAttr for
+// the values without a typed representation.
+func getTypedAttr(f fields.Field, sanitize bool) slog.Attr {
+	key := f.Key
+	if sanitize {
+		key = logencoding.LogfmtKey(key)
+	}
+
+	switch f.Kind() {
+	case fields.KindString:
+		return slog.String(key, f.StringValue())
+	case fields.KindInt64:
+		return slog.Int64(key, f.Int64Value())
+	case fields.KindUint64:
+		return slog.Uint64(key, f.Uint64Value())
+	case fields.KindFloat64:
+		return slog.Float64(key, f.Float64Value())
+	case fields.KindBool:
+		return slog.Bool(key, f.BoolValue())
+	case fields.KindDuration:
+		return slog.Duration(key, f.DurationValue())
+	case fields.KindTime:
+		return slog.Time(key, f.TimeValue())
+	case fields.KindStringer:
+		return slog.String(key, f.StringerValue().String())
+	case fields.KindBytes:
+		return slog.String(key, string(f.BytesValue()))
+	default:
+		return getAttr(f.Key, f.Value(), sanitize)
+	}
+}
+
+// getLevels returns the slog level of the golog level. The trace, fatal and panic levels are mapped to their own slog
+// levels, so their names can be written as they are.
 func getLevels(level levels.Level) slog.Level {
 	levelList := map[levels.Level]slog.Level{
 		levels.NoLevel:    slog.LevelInfo,
 		levels.Disabled:   slog.LevelInfo,
-		levels.TraceLevel: slog.LevelDebug,
+		levels.TraceLevel: slog.LevelDebug - 4,
 		levels.Debug:      slog.LevelDebug,
 		levels.Info:       slog.LevelInfo,
 		levels.Warn:       slog.LevelWarn,
 		levels.Error:      slog.LevelError,
-		levels.Fatal:      slog.LevelError,
-		levels.Panic:      slog.LevelError,
+		levels.Fatal:      slog.LevelError + 4,
+		levels.Panic:      slog.LevelError + 8,
 	}
 
 	sl, exists := levelList[level]
@@ -157,3 +340,22 @@ func getLevels(level levels.Level) slog.Level {
 
 	return sl
 }
+
+func getGologLevel(level slog.Level) levels.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return levels.TraceLevel
+	case level < slog.LevelInfo:
+		return levels.Debug
+	case level < slog.LevelWarn:
+		return levels.Info
+	case level < slog.LevelError:
+		return levels.Warn
+	case level < slog.LevelError+4:
+		return levels.Error
+	case level < slog.LevelError+8:
+		return levels.Fatal
+	default:
+		return levels.Panic
+	}
+}
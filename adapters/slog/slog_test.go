@@ -7,16 +7,19 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/danteay/golog/adaptertest"
 	"github.com/danteay/golog/fields"
 	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
 )
 
 type testMsg struct {
 	Level   string   `json:"level"`
-	Message string   `json:"msg"`
+	Message string   `json:"message"`
 	Error   string   `json:"error"`
 	Key1    string   `json:"key1"`
 	Key2    int      `json:"key2"`
@@ -231,17 +234,268 @@ func TestAdapter_Log(t *testing.T) {
 	})
 }
 
+type valuerError struct{}
+
+func (valuerError) Error() string { return "valuer error" }
+
+func (valuerError) LogValue() slog.Value { return slog.GroupValue(slog.String("code", "E42")) }
+
+func TestAdapter_LogValuerError(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithWriter(&logOutput))
+
+	logger.Log(levels.Error, valuerError{}, nil, "Test message")
+
+	res := testMsg{}
+
+	if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "valuer error", res.Error)
+}
+
+func TestAdapter_LogAt(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger.LogAt(ts, levels.Info, nil, fields.New().Set("key1", "value1"), "Test %s", "message")
+
+	res := struct {
+		testMsg
+		Time time.Time `json:"time"`
+	}{}
+
+	if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+		t.Fatal(errUnmarshall)
+	}
+
+	assert.True(t, ts.Equal(res.Time))
+	assert.Equal(t, "Test message", res.Message)
+	assert.Equal(t, "value1", res.Key1)
+}
+
+func TestAdapter_Formats(t *testing.T) {
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	logFields := fields.New().SetMap(map[string]any{
+		"some key": "some value",
+		"user":     map[string]any{"id": 42, "name": "some-name"},
+	})
+
+	tests := []struct {
+		name     string
+		format   Format
+		expected []string
+	}{
+		{
+			name:   "should encode json",
+			format: JSON,
+			expected: []string{
+				`{"time":"2020-01-01T00:00:00Z","level":"warn","message":"Test message"`,
+				`"some key":"some value"`,
+				`"user":{"id":42,"name":"some-name"}`,
+			},
+		},
+		{
+			name:   "should encode text",
+			format: Text,
+			expected: []string{
+				`time=2020-01-01T00:00:00Z level=warn message="Test message"`,
+				`"some key"="some value"`,
+				`user.id=42 user.name=some-name`,
+			},
+		},
+		{
+			name:   "should encode logfmt",
+			format: Logfmt,
+			expected: []string{
+				`time=2020-01-01T00:00:00Z level=warn message="Test message"`,
+				`some_key="some value"`,
+				`user.id=42 user.name=some-name`,
+			},
+		},
+		{
+			name:   "should encode console",
+			format: Console,
+			expected: []string{
+				"\x1b[90m2020-01-01 00:00:00.000\x1b[0m \x1b[33mWRN\x1b[0m Test message",
+				"\x1b[90msome_key=\x1b[0m\"some value\"",
+				"\x1b[90muser.id=\x1b[0m42 \x1b[90muser.name=\x1b[0msome-name",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var logOutput bytes.Buffer
+
+			logger := New(WithWriter(&logOutput), WithFormat(test.format))
+
+			logger.LogAt(ts, levels.Warn, nil, logFields, "Test %s", "message")
+
+			for _, expected := range test.expected {
+				assert.Contains(t, logOutput.String(), expected)
+			}
+		})
+	}
+}
+
+func TestAdapter_Encoding(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.FixedZone("CST", -6*60*60))
+	logFields := fields.New().Set("user", "john")
+
+	tests := []struct {
+		name     string
+		opts     []logencoding.Option
+		level    levels.Level
+		err      error
+		expected map[string]any
+	}{
+		{
+			name:  "should use the encoding defaults",
+			level: levels.Info,
+			expected: map[string]any{
+				"time":    "2024-01-02T03:04:05.006-06:00",
+				"level":   "info",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+		{
+			name: "should use custom keys, upper case levels and unix times",
+			opts: []logencoding.Option{
+				logencoding.WithTimeKey("ts"),
+				logencoding.WithLevelKey("severity"),
+				logencoding.WithMessageKey("msg"),
+				logencoding.WithErrorKey("err"),
+				logencoding.WithLevelCase(logencoding.Uppercase),
+				logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+			},
+			level: levels.Warn,
+			err:   errors.New("test error"),
+			expected: map[string]any{
+				"ts":       float64(ts.UnixMilli()),
+				"severity": "WARN",
+				"msg":      "Test message",
+				"err":      "test error",
+				"user":     "john",
+			},
+		},
+		{
+			name:  "should use a custom layout on UTC",
+			opts:  []logencoding.Option{logencoding.WithTimeFormat(time.RFC3339), logencoding.WithUTC()},
+			level: levels.Error,
+			expected: map[string]any{
+				"time":    "2024-01-02T09:04:05Z",
+				"level":   "error",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var logOutput bytes.Buffer
+
+			logger := New(WithWriter(&logOutput), WithEncoding(test.opts...))
+
+			logger.LogAt(ts, test.level, test.err, logFields, "Test %s", "message")
+
+			res := map[string]any{}
+
+			if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expected, res)
+		})
+	}
+
+	t.Run("should use the stack key", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(
+			WithLevel(levels.TraceLevel),
+			WithWriter(&logOutput),
+			WithEncoding(logencoding.WithStackKey("trace")),
+		)
+
+		logger.LogAt(ts, levels.TraceLevel, errors.New("test error"), nil, "Test message")
+
+		res := map[string]any{}
+
+		if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "trace", res["level"])
+		assert.NotEmpty(t, res["trace"])
+		assert.NotContains(t, res, "stack")
+	})
+}
+
+func TestAdapter_EncodingLevels(t *testing.T) {
+	tests := map[levels.Level]string{
+		levels.TraceLevel: "TRACE",
+		levels.Debug:      "DEBUG",
+		levels.Info:       "INFO",
+		levels.Warn:       "WARN",
+		levels.Error:      "ERROR",
+	}
+
+	for level, expected := range tests {
+		var logOutput bytes.Buffer
+
+		logger := New(
+			WithLevel(levels.TraceLevel),
+			WithWriter(&logOutput),
+			WithFormat(Logfmt),
+			WithEncoding(logencoding.WithLevelCase(logencoding.Uppercase), logencoding.WithTimeFormat(logencoding.TimeFormatUnix)),
+		)
+
+		logger.LogAt(time.Unix(1577836800, 0), level, nil, nil, "Test message")
+
+		assert.Equal(t, "time=1577836800 level="+expected+" message=\"Test message\"\n", logOutput.String())
+	}
+}
+
+func TestConsoleHandler(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := slog.New(newConsoleHandler(&logOutput, slog.LevelInfo))
+
+	logger.Debug("Test message")
+
+	assert.Equal(t, "", logOutput.String())
+
+	logger.With("key1", "value1").WithGroup("request").With("id", "some id").Error("Test message",
+		slog.Group("user", "id", 42), "error", errors.New("test error"))
+
+	assert.Equal(t, "\x1b[31mERR\x1b[0m Test message \x1b[90mkey1=\x1b[0mvalue1 \x1b[90mrequest.id=\x1b[0m\"some id\" "+
+		"\x1b[90mrequest.user.id=\x1b[0m42 \x1b[90mrequest.error=\x1b[0m\"test error\"\n",
+		strings.SplitN(logOutput.String(), " ", 3)[2])
+}
+
 func TestGetLevels(t *testing.T) {
 	tests := map[levels.Level]slog.Level{
 		levels.NoLevel:    slog.LevelInfo,
 		levels.Disabled:   slog.LevelInfo,
-		levels.TraceLevel: slog.LevelDebug,
+		levels.TraceLevel: slog.LevelDebug - 4,
 		levels.Debug:      slog.LevelDebug,
 		levels.Info:       slog.LevelInfo,
 		levels.Warn:       slog.LevelWarn,
 		levels.Error:      slog.LevelError,
-		levels.Fatal:      slog.LevelError,
-		levels.Panic:      slog.LevelError,
+		levels.Fatal:      slog.LevelError + 4,
+		levels.Panic:      slog.LevelError + 8,
 	}
 
 	for level, expected := range tests {
@@ -257,3 +511,69 @@ func TestGetLevels(t *testing.T) {
 		t.Errorf("Expected level to be Info (default), but got %v", level)
 	}
 }
+
+func TestAdapter_SetExitFunc(t *testing.T) {
+	var (
+		logOutput bytes.Buffer
+		codes     []int
+	)
+
+	logger := New(WithWriter(&logOutput), WithExitFunc(func(int) {
+		t.Error("the replaced exit function was called")
+	}))
+
+	logger.SetExitFunc(func(code int) { codes = append(codes, code) })
+
+	logger.Log(levels.Fatal, nil, nil, "Test message")
+
+	assert.Equal(t, []int{1}, codes)
+	assert.Contains(t, logOutput.String(), "Test message")
+}
+
+func TestAdapter_Panic(t *testing.T) {
+	t.Run("should panic with the error", func(t *testing.T) {
+		errTest := errors.New("test error")
+		logger := New(WithWriter(&bytes.Buffer{}))
+
+		assert.PanicsWithError(t, errTest.Error(), func() {
+			logger.Log(levels.Panic, errTest, nil, "Test message")
+		})
+	})
+
+	t.Run("should panic with the message without error", func(t *testing.T) {
+		logger := New(WithWriter(&bytes.Buffer{}))
+
+		assert.PanicsWithValue(t, "Test message", func() {
+			logger.Log(levels.Panic, nil, nil, "Test %s", "message")
+		})
+	})
+}
+
+func TestAdapter_Conformance(t *testing.T) {
+	t.Run("should pass with the default encoding", func(t *testing.T) {
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+			)
+		})
+	})
+
+	t.Run("should pass with a custom encoding", func(t *testing.T) {
+		encodingOpts := []logencoding.Option{
+			logencoding.WithMessageKey("msg"),
+			logencoding.WithLevelCase(logencoding.Uppercase),
+			logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+		}
+
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+				WithEncoding(encodingOpts...),
+			)
+		}, adaptertest.WithEncoding(logencoding.New(encodingOpts...)))
+	})
+}
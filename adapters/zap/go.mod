@@ -0,0 +1,17 @@
+module github.com/danteay/golog/adapters/zap
+
+go 1.21
+
+require (
+	github.com/danteay/golog/fields v0.1.0
+	github.com/danteay/golog/levels v0.1.1
+	github.com/stretchr/testify v1.9.0
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
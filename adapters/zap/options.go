@@ -0,0 +1,89 @@
+package zap
+
+import (
+	"io"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+type options struct {
+	level     levels.Level
+	writer    io.Writer
+	colored   bool
+	withTrace bool
+	config    logencoding.Config
+	encoder   zapcore.Encoder
+	exitFunc  func(code int)
+	logger    *zap.Logger
+}
+
+// Option defines the signature for the options.
+type Option func(*options)
+
+// WithLevel sets the log level for the logger.
+func WithLevel(level levels.Level) Option {
+	return func(opts *options) {
+		opts.level = level
+	}
+}
+
+// WithWriter sets the writer for the logger.
+func WithWriter(writer io.Writer) Option {
+	return func(opts *options) {
+		opts.writer = writer
+	}
+}
+
+// Colored sets the logger to use colored console output.
+func Colored() Option {
+	return func(opts *options) {
+		opts.colored = true
+	}
+}
+
+// WithTrace sets the error trace for the logger.
+func WithTrace() Option {
+	return func(opts *options) {
+		opts.withTrace = true
+	}
+}
+
+// WithLogger sets a preconfigured zap logger to be used by the adapter, keeping its encoders, outputs and options.
+// When it is set, the WithWriter and Colored options are ignored, and the adapter level is applied on top of the
+// logger level.
+func WithLogger(logger *zap.Logger) Option {
+	return func(opts *options) {
+		opts.logger = logger
+	}
+}
+
+// WithEncoding sets the key names, level casing and time format of the messages, e.g.
+// WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs)). The settings that
+// are not provided take the encoding defaults. It is ignored when WithLogger is set, and the Colored option keeps its
+// colored level names.
+func WithEncoding(opts ...logencoding.Option) Option {
+	return func(o *options) {
+		o.config = logencoding.New(opts...)
+	}
+}
+
+// WithEncoder sets the zap encoder of the messages, replacing the one built with the Colored and WithEncoding options.
+// When WithLogger is set, the logger keeps its own encoder, and this one is only used to write on the writer set with
+// SetWriter, so it should match the encoder of the logger.
+func WithEncoder(encoder zapcore.Encoder) Option {
+	return func(opts *options) {
+		opts.encoder = encoder
+	}
+}
+
+// WithExitFunc sets the function called with the exit code after writing Fatal messages, as a zap fatal hook. It is
+// also applied on the logger set with WithLogger. Defaults to the zap fatal hook, that calls os.Exit.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(opts *options) {
+		opts.exitFunc = exitFunc
+	}
+}
@@ -0,0 +1,114 @@
+package zap
+
+import (
+	"bytes"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+func TestWithLevel(t *testing.T) {
+	opts := &options{}
+	WithLevel(levels.Debug)(opts)
+
+	if opts.level != levels.Debug {
+		t.Errorf("Expected level to be Debug, but got %v", opts.level)
+	}
+}
+
+func TestWithWriter(t *testing.T) {
+	opts := &options{}
+	writer := &bytes.Buffer{}
+	WithWriter(writer)(opts)
+
+	if opts.writer != writer {
+		t.Error("Expected writer to be the provided io.Writer, but it's not")
+	}
+}
+
+func TestColored(t *testing.T) {
+	opts := &options{}
+	Colored()(opts)
+
+	if !opts.colored {
+		t.Error("Expected colored to be true, but it's not")
+	}
+}
+
+func TestWithEncoding(t *testing.T) {
+	opts := &options{}
+	WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithLevelCase(logencoding.Uppercase))(opts)
+
+	if opts.config.MessageKey != "msg" || opts.config.LevelCase != logencoding.Uppercase {
+		t.Errorf("Expected custom encoding config, but got %+v", opts.config)
+	}
+
+	if opts.config.TimeKey != logencoding.DefaultTimeKey {
+		t.Errorf("Expected default time key, but got %q", opts.config.TimeKey)
+	}
+}
+
+func TestWithExitFunc(t *testing.T) {
+	opts := &options{}
+	code := 0
+	WithExitFunc(func(c int) { code = c })(opts)
+
+	opts.exitFunc(1)
+
+	if code != 1 {
+		t.Errorf("Expected exit function to be called with 1, but got %d", code)
+	}
+}
+
+func TestOptionChaining(t *testing.T) {
+	opts := &options{}
+	WithLevel(levels.Error)(opts)
+	writer := &bytes.Buffer{}
+	WithWriter(writer)(opts)
+	Colored()(opts)
+
+	if opts.level != levels.Error {
+		t.Errorf("Expected level to be Error, but got %v", opts.level)
+	}
+
+	if opts.writer != writer {
+		t.Error("Expected writer to be the provided io.Writer, but it's not")
+	}
+
+	if !opts.colored {
+		t.Error("Expected colored to be true, but it's not")
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	opts := &options{}
+	WithTrace()(opts)
+
+	if !opts.withTrace {
+		t.Error("Expected withTrace to be true, but it's not")
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	opts := &options{}
+	logger := zap.NewNop()
+	WithLogger(logger)(opts)
+
+	if opts.logger != logger {
+		t.Error("Expected logger to be the provided zap logger, but it's not")
+	}
+}
+
+func TestWithEncoder(t *testing.T) {
+	opts := &options{}
+	encoder := zapcore.NewConsoleEncoder(zap.NewProductionEncoderConfig())
+	WithEncoder(encoder)(opts)
+
+	if opts.encoder != encoder {
+		t.Error("Expected encoder to be the provided zap encoder, but it's not")
+	}
+}
@@ -0,0 +1,348 @@
+package zap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+// TraceLevel is the zap level used for the trace messages, as zap has no trace level.
+const TraceLevel = zapcore.DebugLevel - 1
+
+// Adapter is a zap adapter implementation
+type Adapter struct {
+	mutex     *sync.RWMutex
+	logger    *zap.Logger
+	atomic    zap.AtomicLevel
+	level     levels.Level
+	writer    io.Writer
+	colored   bool
+	config    logencoding.Config
+	encoder   zapcore.Encoder
+	enabler   zapcore.LevelEnabler
+	withTrace bool
+}
+
+func New(opts ...Option) *Adapter {
+	logOpts := options{
+		level:   levels.Info,
+		colored: false,
+		writer:  os.Stdout,
+		config:  logencoding.New(),
+	}
+
+	for _, opt := range opts {
+		opt(&logOpts)
+	}
+
+	adapter := &Adapter{
+		mutex:     &sync.RWMutex{},
+		atomic:    zap.NewAtomicLevelAt(getLevels(logOpts.level)),
+		level:     logOpts.level,
+		writer:    logOpts.writer,
+		colored:   logOpts.colored,
+		config:    logOpts.config,
+		encoder:   logOpts.encoder,
+		withTrace: logOpts.withTrace,
+	}
+
+	if adapter.encoder == nil {
+		adapter.encoder = getEncoder(adapter.colored, adapter.config)
+	}
+
+	adapter.enabler = adapter.atomic
+
+	var zapOpts []zap.Option
+	if logOpts.exitFunc != nil {
+		zapOpts = append(zapOpts, zap.WithFatalHook(exitHook(logOpts.exitFunc)))
+	}
+
+	if logOpts.logger != nil {
+		adapter.logger = logOpts.logger.WithOptions(zapOpts...)
+		adapter.enabler = logOpts.logger.Core()
+
+		return adapter
+	}
+
+	adapter.logger = zap.New(adapter.newCore(adapter.writer), zapOpts...)
+
+	return adapter
+}
+
+// Writer returns the writer for the adapter
+func (a *Adapter) Writer() io.Writer {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.writer
+}
+
+// SetWriter sets the writer for the adapter. If the adapter wraps a preconfigured zap logger, its core is replaced by
+// a new one that writes on w with the encoder set with WithEncoder, keeping the level of the original core and the
+// rest of the logger options. The fields added to the zap logger with With are not kept, as they are part of the
+// original core.
+func (a *Adapter) SetWriter(w io.Writer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.writer = w
+	a.logger = a.logger.WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+		return a.newCore(w)
+	}))
+}
+
+// Level returns the level for the adapter
+func (a *Adapter) Level() levels.Level {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.level
+}
+
+// SetLevel sets the level for the adapter
+func (a *Adapter) SetLevel(level levels.Level) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.level = level
+	a.atomic.SetLevel(getLevels(level))
+}
+
+// SetExitFunc sets the function called with the exit code after writing Fatal messages, replacing the fatal hook of
+// the zap logger.
+func (a *Adapter) SetExitFunc(exitFunc func(code int)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.logger = a.logger.WithOptions(zap.WithFatalHook(exitHook(exitFunc)))
+}
+
+// Logger returns the zap logger instance
+func (a *Adapter) Logger() *zap.Logger {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.logger
+}
+
+// Log logs a message with the given level, error, fields, and message
+func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt logs a message with the given timestamp, level, error, fields, and message. Fatal messages exit the program
+// and Panic messages panic after being written, as zap does, with the error when it is set and with the message
+// otherwise.
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	a.write(t, level, err, logFields, fmt.Sprintf(msg, args...), true)
+}
+
+// Emit writes a message with the given timestamp, level, error, fields, and message, without exiting the program
+// after Fatal messages or panicking after Panic messages, so the caller can terminate on its own.
+func (a *Adapter) Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	a.write(t, level, err, logFields, fmt.Sprintf(msg, args...), false)
+}
+
+// write writes the message with the zap logger. When terminate is false, the fatal and panic hooks of the logger are
+// replaced on the checked entry, so it is only written.
+func (a *Adapter) write(t time.Time, level levels.Level, err error, logFields *fields.Fields, message string, terminate bool) {
+	zapLevel := getLevels(level)
+
+	// fatal and panic messages are checked anyway, so zap exits or panics even when they are not written
+	if (level < levels.Fatal || !terminate) && !a.atomic.Enabled(zapLevel) {
+		return
+	}
+
+	entry := a.Logger().Check(zapLevel, message)
+	if entry == nil {
+		return
+	}
+
+	entry.Time = t
+
+	switch {
+	case !terminate:
+		entry = entry.After(entry.Entry, zapcore.WriteThenNoop)
+	case level == levels.Panic && err != nil:
+		entry = entry.After(entry.Entry, panicHook{err: err})
+	}
+
+	entry.Write(a.getFields(level, err, logFields)...)
+}
+
+// panicHook is a zap hook that panics with the error after writing panic messages, instead of the message.
+type panicHook struct {
+	err error
+}
+
+// OnWrite panics with the error of the hook.
+func (h panicHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	panic(h.err)
+}
+
+// exitHook is a zap hook that calls the exit function after writing fatal messages.
+type exitHook func(code int)
+
+// OnWrite calls the exit function with the exit code 1.
+func (h exitHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	h(1)
+}
+
+func (a *Adapter) newCore(w io.Writer) zapcore.Core {
+	return zapcore.NewCore(a.encoder, zapcore.AddSync(w), a.enabler)
+}
+
+func (a *Adapter) getFields(level levels.Level, err error, logFields *fields.Fields) []zap.Field {
+	var zapFields []zap.Field
+
+	if err != nil {
+		zapFields = append(zapFields, zap.NamedError(a.config.ErrorKey, err))
+
+		withStack := a.withTrace || level == levels.TraceLevel
+		if withStack && !logencoding.HasStack(logFields, a.config.StackKey, logencoding.DefaultStackKey) {
+			zapFields = append(zapFields, zap.Strings(a.config.StackKey, logencoding.StackTrace()))
+		}
+	}
+
+	if logFields != nil {
+		logFields.RangeFields(func(f fields.Field) bool {
+			zapFields = append(zapFields, getTypedField(f))
+			return true
+		})
+	}
+
+	return zapFields
+}
+
+// getTypedField returns the zap field with the constructor of the field kind, falling back to zap.Any for the values
+// without a typed representation. Times are written as RFC 3339 strings, as zap would encode them with the time
+// encoder of the message timestamp.
+func getTypedField(f fields.Field) zap.Field {
+	switch f.Kind() {
+	case fields.KindString:
+		return zap.String(f.Key, f.StringValue())
+	case fields.KindInt64:
+		return zap.Int64(f.Key, f.Int64Value())
+	case fields.KindUint64:
+		return zap.Uint64(f.Key, f.Uint64Value())
+	case fields.KindFloat64:
+		return zap.Float64(f.Key, f.Float64Value())
+	case fields.KindBool:
+		return zap.Bool(f.Key, f.BoolValue())
+	case fields.KindDuration:
+		return zap.Duration(f.Key, f.DurationValue())
+	case fields.KindTime:
+		return zap.String(f.Key, f.TimeValue().Format(time.RFC3339Nano))
+	case fields.KindStringer:
+		return zap.Stringer(f.Key, f.StringerValue())
+	case fields.KindBytes:
+		return zap.ByteString(f.Key, f.BytesValue())
+	default:
+		if t, ok := f.Value().(time.Time); ok {
+			return zap.String(f.Key, t.Format(time.RFC3339Nano))
+		}
+
+		return zap.Any(f.Key, f.Value())
+	}
+}
+
+func getEncoder(colored bool, encodingConfig logencoding.Config) zapcore.Encoder {
+	config := zap.NewProductionEncoderConfig()
+	config.TimeKey = encodingConfig.TimeKey
+	config.LevelKey = encodingConfig.LevelKey
+	config.MessageKey = encodingConfig.MessageKey
+	config.EncodeTime = timeEncoder(encodingConfig)
+
+	if colored {
+		config.EncodeLevel = levelEncoder(zapcore.CapitalColorLevelEncoder, "\x1b[35mTRACE\x1b[0m")
+		return zapcore.NewConsoleEncoder(config)
+	}
+
+	config.EncodeLevel = func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(encodingConfig.Level(getGologLevel(level)))
+	}
+
+	return zapcore.NewJSONEncoder(config)
+}
+
+// timeEncoder encodes the times with the format of the encoding config, as numbers for the Unix formats.
+func timeEncoder(config logencoding.Config) zapcore.TimeEncoder {
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		if config.IsNumericTime() {
+			enc.AppendInt64(config.Time(t).(int64))
+			return
+		}
+
+		enc.AppendString(config.FormatTime(t))
+	}
+}
+
+// levelEncoder encodes the trace level with the provided name, and the rest of the levels with the base encoder.
+func levelEncoder(base zapcore.LevelEncoder, trace string) zapcore.LevelEncoder {
+	return func(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		if level == TraceLevel {
+			enc.AppendString(trace)
+			return
+		}
+
+		base(level, enc)
+	}
+}
+
+func getLevels(level levels.Level) zapcore.Level {
+	levelList := map[levels.Level]zapcore.Level{
+		levels.NoLevel:    TraceLevel,
+		levels.Disabled:   zapcore.InvalidLevel,
+		levels.TraceLevel: TraceLevel,
+		levels.Debug:      zapcore.DebugLevel,
+		levels.Info:       zapcore.InfoLevel,
+		levels.Warn:       zapcore.WarnLevel,
+		levels.Error:      zapcore.ErrorLevel,
+		levels.Fatal:      zapcore.FatalLevel,
+		levels.Panic:      zapcore.PanicLevel,
+	}
+
+	zl, exists := levelList[level]
+	if !exists {
+		return zapcore.InfoLevel
+	}
+
+	return zl
+}
+
+func getGologLevel(level zapcore.Level) levels.Level {
+	levelList := map[zapcore.Level]levels.Level{
+		TraceLevel:          levels.TraceLevel,
+		zapcore.DebugLevel:  levels.Debug,
+		zapcore.InfoLevel:   levels.Info,
+		zapcore.WarnLevel:   levels.Warn,
+		zapcore.ErrorLevel:  levels.Error,
+		zapcore.DPanicLevel: levels.Error,
+		zapcore.PanicLevel:  levels.Panic,
+		zapcore.FatalLevel:  levels.Fatal,
+	}
+
+	gl, exists := levelList[level]
+	if !exists {
+		return levels.Info
+	}
+
+	return gl
+}
@@ -0,0 +1,131 @@
+package zerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/danteay/golog/logencoding"
+)
+
+// logfmtWriter is a writer that converts the JSON lines written by zerolog into logfmt lines, writing the time, level
+// and message first, and flattening nested objects with dot separated keys. The time, level and message keys are the
+// ones of the encoding config.
+//
+// As zerolog only encodes JSON, every line is encoded by zerolog and decoded again here, which costs a few allocations
+// per field on top of the zerolog ones. Use the native adapter when logfmt is needed on hot paths.
+type logfmtWriter struct {
+	out    io.Writer
+	config logencoding.Config
+}
+
+type logfmtPair struct {
+	key   string
+	value string
+}
+
+// Write converts the JSON line in p and writes it on the underlying writer. Lines that are not valid JSON objects are
+// written as they are.
+func (w logfmtWriter) Write(p []byte) (int, error) {
+	var pairs []logfmtPair
+
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	if err := decodeObject(decoder, "", &pairs); err != nil {
+		return w.out.Write(p)
+	}
+
+	line := make([]byte, 0, len(p))
+	firstKeys := w.firstKeys()
+
+	for _, key := range firstKeys {
+		for _, pair := range pairs {
+			if pair.key == key {
+				line = appendLogfmtPair(line, pair)
+			}
+		}
+	}
+
+	for _, pair := range pairs {
+		if !slices.Contains(firstKeys, pair.key) {
+			line = appendLogfmtPair(line, pair)
+		}
+	}
+
+	line = append(line, '\n')
+
+	if _, err := w.out.Write(line); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (w logfmtWriter) firstKeys() []string {
+	return []string{w.config.TimeKey, w.config.LevelKey, w.config.MessageKey}
+}
+
+// decodeObject reads the next JSON object of the decoder, adding its values to pairs with the given key prefix.
+func decodeObject(decoder *json.Decoder, prefix string, pairs *[]logfmtPair) error {
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		key, _ := token.(string)
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+
+		if err := addValue(raw, prefix+key, pairs); err != nil {
+			return err
+		}
+	}
+
+	_, err := decoder.Token()
+
+	return err
+}
+
+func addValue(raw json.RawMessage, key string, pairs *[]logfmtPair) error {
+	trimmed := bytes.TrimSpace(raw)
+
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+
+		return decodeObject(decoder, key+".", pairs)
+	case len(trimmed) > 0 && trimmed[0] == '"':
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return err
+		}
+
+		*pairs = append(*pairs, logfmtPair{key: key, value: s})
+	default:
+		*pairs = append(*pairs, logfmtPair{key: key, value: string(trimmed)})
+	}
+
+	return nil
+}
+
+func appendLogfmtPair(line []byte, pair logfmtPair) []byte {
+	if len(line) > 0 {
+		line = append(line, ' ')
+	}
+
+	line = logencoding.AppendLogfmtKey(line, pair.key)
+	line = append(line, '=')
+
+	return logencoding.AppendLogfmtValue(line, pair.value)
+}
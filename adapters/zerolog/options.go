@@ -4,13 +4,35 @@ import (
 	"io"
 
 	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+// Format defines the encoding of the log messages.
+type Format int
+
+const (
+	// JSON encodes every message as a JSON object.
+	JSON Format = iota
+
+	// Text encodes every message as a human-readable line without colors, using the zerolog console writer.
+	Text
+
+	// Logfmt encodes every message as a line of key=value pairs. The lines are encoded as JSON by zerolog and then
+	// converted, so it is slower than JSON and allocates on every message.
+	Logfmt
+
+	// Console encodes every message as a human-readable line with colors, using the zerolog console writer.
+	Console
 )
 
 type options struct {
 	level     levels.Level
 	writer    io.Writer
+	format    Format
 	colored   bool
 	withTrace bool
+	config    logencoding.Config
+	exitFunc  func(code int)
 }
 
 // Option defines the signature for the options.
@@ -30,7 +52,15 @@ func WithWriter(writer io.Writer) Option {
 	}
 }
 
-// Colored sets the logger to use colored output.
+// WithFormat sets the encoding of the log messages. Defaults to JSON. Nested fields are written as nested objects on
+// JSON, and flattened with dot separated keys on the rest of the formats, e.g. "user.id=42".
+func WithFormat(format Format) Option {
+	return func(opts *options) {
+		opts.format = format
+	}
+}
+
+// Colored sets the logger to use colored output. It is the same as WithFormat(Console).
 func Colored() Option {
 	return func(opts *options) {
 		opts.colored = true
@@ -43,3 +73,20 @@ func WithTrace() Option {
 		opts.withTrace = true
 	}
 }
+
+// WithEncoding sets the key names, level casing and time format of the messages, e.g.
+// WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs)). The
+// settings that are not provided take the logencoding defaults, that are also used without this option. The Text and
+// Console formats keep the zerolog console layout, so only the error and stack keys are used on them.
+func WithEncoding(opts ...logencoding.Option) Option {
+	return func(o *options) {
+		o.config = logencoding.New(opts...)
+	}
+}
+
+// WithExitFunc sets the function called with the exit code after writing Fatal messages. Defaults to os.Exit.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(opts *options) {
+		opts.exitFunc = exitFunc
+	}
+}
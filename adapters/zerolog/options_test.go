@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
 )
 
 func TestWithLevel(t *testing.T) {
@@ -35,6 +36,40 @@ func TestColored(t *testing.T) {
 	}
 }
 
+func TestWithFormat(t *testing.T) {
+	opts := &options{}
+	WithFormat(Logfmt)(opts)
+
+	if opts.format != Logfmt {
+		t.Errorf("Expected format to be Logfmt, but got %v", opts.format)
+	}
+}
+
+func TestWithEncoding(t *testing.T) {
+	opts := &options{}
+	WithEncoding(logencoding.WithMessageKey("msg"), logencoding.WithLevelCase(logencoding.Uppercase))(opts)
+
+	if opts.config.MessageKey != "msg" || opts.config.LevelCase != logencoding.Uppercase {
+		t.Errorf("Expected custom encoding config, but got %+v", opts.config)
+	}
+
+	if opts.config.TimeKey != logencoding.DefaultTimeKey {
+		t.Errorf("Expected default time key, but got %q", opts.config.TimeKey)
+	}
+}
+
+func TestWithExitFunc(t *testing.T) {
+	opts := &options{}
+	code := 0
+	WithExitFunc(func(c int) { code = c })(opts)
+
+	opts.exitFunc(1)
+
+	if code != 1 {
+		t.Errorf("Expected exit function to be called with 1, but got %d", code)
+	}
+}
+
 func TestOptionChaining(t *testing.T) {
 	opts := &options{}
 	WithLevel(levels.Error)(opts)
@@ -4,19 +4,25 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"runtime/debug"
-	"strings"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/danteay/golog/fields"
 	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
 	"github.com/rs/zerolog"
 )
 
 // Adapter is a zerolog adapter implementation
 type Adapter struct {
+	mutex     *sync.RWMutex
 	logger    zerolog.Logger
 	level     levels.Level
 	writer    io.Writer
+	format    Format
+	config    logencoding.Config
+	exitFunc  func(code int)
 	withTrace bool
 }
 
@@ -25,15 +31,28 @@ func New(opts ...Option) *Adapter {
 		level:   levels.Info,
 		colored: false,
 		writer:  os.Stdout,
+		config:  logencoding.New(),
 	}
 
 	for _, opt := range opts {
 		opt(&logOpts)
 	}
 
+	if logOpts.exitFunc == nil {
+		logOpts.exitFunc = os.Exit
+	}
+
+	if logOpts.colored {
+		logOpts.format = Console
+	}
+
 	adapter := &Adapter{
+		mutex:     &sync.RWMutex{},
 		level:     logOpts.level,
-		writer:    getWriter(logOpts.writer, logOpts.colored),
+		writer:    getWriter(logOpts.writer, logOpts.format, logOpts.config),
+		format:    logOpts.format,
+		config:    logOpts.config,
+		exitFunc:  logOpts.exitFunc,
 		withTrace: logOpts.withTrace,
 	}
 
@@ -44,84 +63,185 @@ func New(opts ...Option) *Adapter {
 
 // Writer returns the writer for the adapter
 func (a *Adapter) Writer() io.Writer {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
 	return a.writer
 }
 
 // SetWriter sets the writer for the adapter
 func (a *Adapter) SetWriter(w io.Writer) {
-	a.writer = w
-	a.logger = a.logger.Output(w)
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.writer = getWriter(w, a.format, a.config)
+	a.logger = a.logger.Output(a.writer)
 }
 
 // Level returns the level for the adapter
 func (a *Adapter) Level() levels.Level {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
 	return a.level
 }
 
 // SetLevel sets the level for the adapter
 func (a *Adapter) SetLevel(level levels.Level) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
 	a.level = level
 	a.logger = a.logger.Level(getLevels(level))
 }
 
-// Logger returns the zerolog logger instance
+// SetExitFunc sets the function called with the exit code after writing Fatal messages.
+func (a *Adapter) SetExitFunc(exitFunc func(code int)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.exitFunc = exitFunc
+}
+
+// Logger returns the zerolog logger instance, that adds a timestamp to the messages written with it directly. The
+// adapter logger writes the time of every message itself, so the timestamp context is only added to the returned one.
 func (a *Adapter) Logger() zerolog.Logger {
-	return a.logger
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.logger.With().Timestamp().Logger()
 }
 
 // Log logs a message with the given level, error, fields, and message
 func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt logs a message with the given timestamp, level, error, fields, and message. Fatal messages exit the program
+// and Panic messages panic after being written, with the error when it is set and with the message otherwise.
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
 	if level <= levels.Disabled {
 		return
 	}
 
-	log := a.getLog(level)
+	message := a.emit(t, level, err, logFields, msg, args)
 
-	addErrFields(level, err, log, a.withTrace)
+	switch {
+	case level == levels.Fatal:
+		a.mutex.RLock()
+		exitFunc := a.exitFunc
+		a.mutex.RUnlock()
 
-	if logFields != nil {
-		for k, v := range logFields.Data() {
-			log = log.Interface(k, v)
-		}
+		exitFunc(1)
+	case level == levels.Panic && err != nil:
+		panic(err)
+	case level == levels.Panic:
+		panic(message)
 	}
+}
 
-	log.Msg(fmt.Sprintf(msg, args...))
+// Emit writes a message with the given timestamp, level, error, fields, and message, without exiting the program
+// after Fatal messages or panicking after Panic messages, so the caller can terminate on its own.
+func (a *Adapter) Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	a.emit(t, level, err, logFields, msg, args)
 }
 
-func (a *Adapter) getLog(level levels.Level) *zerolog.Event {
-	events := map[levels.Level]func() *zerolog.Event{
-		levels.TraceLevel: a.logger.Trace,
-		levels.Debug:      a.logger.Debug,
-		levels.Info:       a.logger.Info,
-		levels.Warn:       a.logger.Warn,
-		levels.Error:      a.logger.Error,
-		levels.Fatal:      a.logger.Fatal,
-		levels.Panic:      a.logger.Panic,
+func (a *Adapter) emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args []any) string {
+	message := fmt.Sprintf(msg, args...)
+
+	a.mutex.RLock()
+	logger := a.logger
+	a.mutex.RUnlock()
+
+	if a.format == JSON || a.format == Logfmt {
+		a.logEncoded(&logger, t, level, err, logFields, message)
+	} else {
+		a.log(&logger, t, level, err, logFields, message)
 	}
 
-	event, exists := events[level]
-	if !exists {
-		return a.logger.Info()
+	return message
+}
+
+func (a *Adapter) log(logger *zerolog.Logger, t time.Time, level levels.Level, err error, logFields *fields.Fields, message string) {
+	log := getLog(logger, level).Time(zerolog.TimestampFieldName, t)
+
+	a.addErrFields(level, err, logFields, log)
+
+	if logFields != nil {
+		logFields.RangeFields(func(f fields.Field) bool {
+			log = addTypedField(log, f, a.format != JSON)
+			return true
+		})
 	}
 
-	return event()
+	log.Msg(message)
 }
 
-func addErrFields(level levels.Level, err error, evt *zerolog.Event, withTrace bool) {
-	if err == nil {
-		return
+// getLog returns the event for the level. Fatal and panic events are created with WithLevel, so zerolog does not exit
+// or panic by itself and the adapter can do it with its exit function.
+func getLog(logger *zerolog.Logger, level levels.Level) *zerolog.Event {
+	switch level {
+	case levels.TraceLevel:
+		return logger.Trace()
+	case levels.Debug:
+		return logger.Debug()
+	case levels.Warn:
+		return logger.Warn()
+	case levels.Error:
+		return logger.Error()
+	case levels.Fatal:
+		return logger.WithLevel(zerolog.FatalLevel)
+	case levels.Panic:
+		return logger.WithLevel(zerolog.PanicLevel)
+	default:
+		return logger.Info()
 	}
+}
+
+// logEncoded writes the message with the time, level and message keys of the encoding config. As zerolog takes these
+// keys from global variables, the message is written as an event without level and the keys are added as fields.
+func (a *Adapter) logEncoded(logger *zerolog.Logger, t time.Time, level levels.Level, err error, logFields *fields.Fields, message string) {
+	if getLevels(level) >= logger.GetLevel() {
+		log := logger.Log()
 
-	evt.Err(err)
+		if a.config.IsNumericTime() {
+			log.Int64(a.config.TimeKey, a.config.Time(t).(int64))
+		} else {
+			log.Str(a.config.TimeKey, a.config.FormatTime(t))
+		}
+
+		log.Str(a.config.LevelKey, a.config.Level(level)).Str(a.config.MessageKey, message)
 
-	if withTrace || level == levels.TraceLevel {
-		evt.Interface("stack", getStackTrace())
+		a.addErrFields(level, err, logFields, log)
+
+		if logFields != nil {
+			logFields.RangeFields(func(f fields.Field) bool {
+				log = addTypedField(log, f, a.format != JSON)
+				return true
+			})
+		}
+
+		log.Send()
 	}
 }
 
-func getStackTrace() []string {
-	stack := strings.ReplaceAll(string(debug.Stack()), "\t", "")
-	return strings.Split(stack, "\n")
+func (a *Adapter) addErrFields(level levels.Level, err error, logFields *fields.Fields, evt *zerolog.Event) {
+	if err == nil {
+		return
+	}
+
+	errorKey, stackKey := a.config.ErrorKey, a.config.StackKey
+
+	evt.AnErr(errorKey, err)
+
+	withStack := a.withTrace || level == levels.TraceLevel
+	if withStack && !logencoding.HasStack(logFields, stackKey, logencoding.DefaultStackKey) {
+		evt.Interface(stackKey, logencoding.StackTrace())
+	}
 }
 
 func getLevels(level levels.Level) zerolog.Level {
@@ -145,17 +265,68 @@ func getLevels(level levels.Level) zerolog.Level {
 	return zl
 }
 
-func getWriter(baseWriter io.Writer, colored bool) io.Writer {
-	if colored {
-		return zerolog.ConsoleWriter{Out: baseWriter}
+// addField adds the field to the event. When flatten is set, nested maps are added as separate fields with dot
+// separated keys, e.g. "user.id".
+func addField(evt *zerolog.Event, key string, value any, flatten bool) *zerolog.Event {
+	nested, ok := value.(map[string]any)
+	if !ok || !flatten || len(nested) == 0 {
+		return evt.Interface(key, value)
+	}
+
+	keys := make([]string, 0, len(nested))
+	for k := range nested {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		evt = addField(evt, key+"."+k, nested[k], flatten)
 	}
 
-	return baseWriter
+	return evt
+}
+
+// addTypedField adds the field with the zerolog method of its kind, falling back to addField for the values without a
+// typed representation. Durations and times are written with the zerolog duration unit and time format.
+func addTypedField(evt *zerolog.Event, f fields.Field, flatten bool) *zerolog.Event {
+	switch f.Kind() {
+	case fields.KindString:
+		return evt.Str(f.Key, f.StringValue())
+	case fields.KindInt64:
+		return evt.Int64(f.Key, f.Int64Value())
+	case fields.KindUint64:
+		return evt.Uint64(f.Key, f.Uint64Value())
+	case fields.KindFloat64:
+		return evt.Float64(f.Key, f.Float64Value())
+	case fields.KindBool:
+		return evt.Bool(f.Key, f.BoolValue())
+	case fields.KindDuration:
+		return evt.Dur(f.Key, f.DurationValue())
+	case fields.KindTime:
+		return evt.Time(f.Key, f.TimeValue())
+	case fields.KindStringer:
+		return evt.Stringer(f.Key, f.StringerValue())
+	case fields.KindBytes:
+		return evt.Bytes(f.Key, f.BytesValue())
+	default:
+		return addField(evt, f.Key, f.Value(), flatten)
+	}
+}
+
+func getWriter(baseWriter io.Writer, format Format, config logencoding.Config) io.Writer {
+	switch format {
+	case Console:
+		return zerolog.ConsoleWriter{Out: baseWriter}
+	case Text:
+		return zerolog.ConsoleWriter{Out: baseWriter, NoColor: true}
+	case Logfmt:
+		return logfmtWriter{out: baseWriter, config: config}
+	default:
+		return baseWriter
+	}
 }
 
 func getLogger(level levels.Level, writer io.Writer) zerolog.Logger {
-	return zerolog.New(writer).
-		With().Timestamp().
-		Logger().
-		Level(getLevels(level))
+	return zerolog.New(writer).Level(getLevels(level))
 }
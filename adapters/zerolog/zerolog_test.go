@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/danteay/golog/adaptertest"
 	"github.com/danteay/golog/fields"
 	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
 )
 
 type testMsg struct {
@@ -197,6 +200,233 @@ func TestAdapter_Log(t *testing.T) {
 	})
 }
 
+func TestAdapter_LogAt(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithLevel(levels.Debug), WithWriter(&logOutput))
+
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger.LogAt(ts, levels.Info, nil, fields.New().Set("key1", "value1"), "Test %s", "message")
+
+	res := struct {
+		testMsg
+		Time time.Time `json:"time"`
+	}{}
+
+	if errUnmarshall := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshall != nil {
+		t.Fatal(errUnmarshall)
+	}
+
+	assert.True(t, ts.Equal(res.Time))
+	assert.Equal(t, "Test message", res.Message)
+	assert.Equal(t, "value1", res.Key1)
+}
+
+func TestAdapter_Formats(t *testing.T) {
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	logFields := fields.New().SetMap(map[string]any{
+		"some key": "some value",
+		"user":     map[string]any{"id": 42, "name": "some-name"},
+	})
+
+	tests := []struct {
+		name     string
+		format   Format
+		expected []string
+	}{
+		{
+			name:   "should encode json",
+			format: JSON,
+			expected: []string{
+				`"level":"warn"`,
+				`"time":"2020-01-01T00:00:00Z"`,
+				`"message":"Test message"`,
+				`"user":{"id":42,"name":"some-name"}`,
+			},
+		},
+		{
+			name:   "should encode text",
+			format: Text,
+			expected: []string{
+				`WRN Test message`,
+				`some key="some value"`,
+				`user.id=42`,
+				`user.name=some-name`,
+			},
+		},
+		{
+			name:   "should encode logfmt",
+			format: Logfmt,
+			expected: []string{
+				`time=2020-01-01T00:00:00Z level=warn message="Test message"`,
+				`some_key="some value"`,
+				`user.id=42`,
+				`user.name=some-name`,
+			},
+		},
+		{
+			name:   "should encode console",
+			format: Console,
+			expected: []string{
+				"\x1b[33mWRN\x1b[0m",
+				`user.id=`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var logOutput bytes.Buffer
+
+			logger := New(WithWriter(&logOutput), WithFormat(test.format))
+
+			logger.LogAt(ts, levels.Warn, nil, logFields, "Test %s", "message")
+
+			for _, expected := range test.expected {
+				assert.Contains(t, logOutput.String(), expected)
+			}
+		})
+	}
+}
+
+func TestAdapter_Encoding(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.FixedZone("CST", -6*60*60))
+	logFields := fields.New().Set("user", "john")
+
+	tests := []struct {
+		name     string
+		opts     []logencoding.Option
+		level    levels.Level
+		err      error
+		expected map[string]any
+	}{
+		{
+			name:  "should use the encoding defaults",
+			level: levels.Info,
+			expected: map[string]any{
+				"time":    "2024-01-02T03:04:05.006-06:00",
+				"level":   "info",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+		{
+			name: "should use custom keys, upper case levels and unix times",
+			opts: []logencoding.Option{
+				logencoding.WithTimeKey("ts"),
+				logencoding.WithLevelKey("severity"),
+				logencoding.WithMessageKey("msg"),
+				logencoding.WithErrorKey("err"),
+				logencoding.WithLevelCase(logencoding.Uppercase),
+				logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+			},
+			level: levels.Warn,
+			err:   errors.New("test error"),
+			expected: map[string]any{
+				"ts":       float64(ts.UnixMilli()),
+				"severity": "WARN",
+				"msg":      "Test message",
+				"err":      "test error",
+				"user":     "john",
+			},
+		},
+		{
+			name:  "should use a custom layout on UTC",
+			opts:  []logencoding.Option{logencoding.WithTimeFormat(time.RFC3339), logencoding.WithUTC()},
+			level: levels.Error,
+			expected: map[string]any{
+				"time":    "2024-01-02T09:04:05Z",
+				"level":   "error",
+				"message": "Test message",
+				"user":    "john",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var logOutput bytes.Buffer
+
+			logger := New(WithWriter(&logOutput), WithEncoding(test.opts...))
+
+			logger.LogAt(ts, test.level, test.err, logFields, "Test %s", "message")
+
+			res := map[string]any{}
+
+			if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expected, res)
+		})
+	}
+
+	t.Run("should use the stack key", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(
+			WithLevel(levels.TraceLevel),
+			WithWriter(&logOutput),
+			WithEncoding(logencoding.WithStackKey("trace")),
+		)
+
+		logger.LogAt(ts, levels.TraceLevel, errors.New("test error"), nil, "Test message")
+
+		res := map[string]any{}
+
+		if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "trace", res["level"])
+		assert.NotEmpty(t, res["trace"])
+		assert.NotContains(t, res, "stack")
+	})
+}
+
+func TestLogfmtWriter(t *testing.T) {
+	t.Run("should convert json lines", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		w := logfmtWriter{out: &logOutput, config: logencoding.New()}
+
+		line := `{"level":"info","error":"some \"quoted\" error","nested":{"key":"value","deep":{"num":1.5}},"list":[1,2],"empty":"","time":"2020-01-01T00:00:00Z","message":"Test message"}` + "\n"
+
+		n, err := w.Write([]byte(line))
+
+		assert.NoError(t, err)
+		assert.Equal(t, len(line), n)
+		assert.Equal(t, `time=2020-01-01T00:00:00Z level=info message="Test message" error="some \"quoted\" error" `+
+			`nested.key=value nested.deep.num=1.5 list=[1,2] empty=""`+"\n", logOutput.String())
+	})
+
+	t.Run("should write the encoding keys first", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		config := logencoding.New(logencoding.WithTimeKey("ts"), logencoding.WithMessageKey("msg"))
+		w := logfmtWriter{out: &logOutput, config: config}
+
+		_, err := w.Write([]byte(`{"key":"value","msg":"Test message","level":"info","ts":1577836800000}` + "\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `ts=1577836800000 level=info msg="Test message" key=value`+"\n", logOutput.String())
+	})
+
+	t.Run("should write invalid lines as they are", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		_, err := logfmtWriter{out: &logOutput}.Write([]byte("not json\n"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "not json\n", logOutput.String())
+	})
+}
+
 func TestGetLevels(t *testing.T) {
 	tests := map[levels.Level]zerolog.Level{
 		levels.Debug: zerolog.DebugLevel,
@@ -220,3 +450,71 @@ func TestGetLevels(t *testing.T) {
 		t.Errorf("Expected level to be Info (default), but got %v", level)
 	}
 }
+
+func TestAdapter_Logger(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithLevel(levels.Warn), WithWriter(&logOutput))
+
+	zlog := logger.Logger()
+	zlog.Info().Msg("Filtered message")
+	zlog.Warn().Msg("Test message")
+
+	res := map[string]any{}
+
+	if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, zerolog.WarnLevel, zlog.GetLevel())
+	assert.Equal(t, "warn", res["level"])
+	assert.Equal(t, "Test message", res["message"])
+	assert.NotEmpty(t, res[zerolog.TimestampFieldName])
+}
+
+func TestAdapter_SetExitFunc(t *testing.T) {
+	var (
+		logOutput bytes.Buffer
+		codes     []int
+	)
+
+	logger := New(WithWriter(&logOutput), WithExitFunc(func(int) {
+		t.Error("the replaced exit function was called")
+	}))
+
+	logger.SetExitFunc(func(code int) { codes = append(codes, code) })
+
+	logger.Log(levels.Fatal, nil, nil, "Test message")
+
+	assert.Equal(t, []int{1}, codes)
+	assert.Contains(t, logOutput.String(), "Test message")
+}
+
+func TestAdapter_Conformance(t *testing.T) {
+	t.Run("should pass with the default encoding", func(t *testing.T) {
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+			)
+		})
+	})
+
+	t.Run("should pass with a custom encoding", func(t *testing.T) {
+		encodingOpts := []logencoding.Option{
+			logencoding.WithMessageKey("msg"),
+			logencoding.WithLevelCase(logencoding.Uppercase),
+			logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+		}
+
+		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+			return New(
+				WithWriter(config.Writer),
+				WithLevel(config.Level),
+				WithExitFunc(config.ExitFunc),
+				WithEncoding(encodingOpts...),
+			)
+		}, adaptertest.WithEncoding(logencoding.New(encodingOpts...)))
+	})
+}
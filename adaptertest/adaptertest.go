@@ -0,0 +1,149 @@
+// Package adaptertest provides a conformance test suite for golog adapters. Any adapter implementation, built-in or
+// third-party, can run it from its own tests to verify that it behaves as the rest of the adapters:
+//
+//	func TestAdapter_Conformance(t *testing.T) {
+//		adaptertest.Run(t, func(config adaptertest.Config) adaptertest.Adapter {
+//			return New(
+//				WithWriter(config.Writer),
+//				WithLevel(config.Level),
+//				WithExitFunc(config.ExitFunc),
+//			)
+//		})
+//	}
+//
+// The adapter must write every message as a JSON object on a single line, with the keys of the encoding config, that
+// defaults to logencoding.New().
+package adaptertest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+// Adapter is the interface of the adapters under test, that is the same as golog.Adapter.
+type Adapter interface {
+	Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+	Writer() io.Writer
+	SetWriter(w io.Writer)
+	Level() levels.Level
+	SetLevel(level levels.Level)
+}
+
+// TimedAdapter is implemented by the adapters that can log a message with a given timestamp, that is the same as
+// golog.TimedAdapter. The written time is verified when the adapter implements it.
+type TimedAdapter interface {
+	LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+// Emitter is implemented by the adapters that can write Fatal and Panic messages without terminating, that is the same
+// as golog.Emitter. The suite verifies that Emit neither exits nor panics when the adapter implements it.
+type Emitter interface {
+	Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+// ExitFuncSetter is implemented by the adapters whose exit function can be replaced after they are created. The suite
+// verifies that it can be replaced while Fatal messages are written when the adapter implements it.
+type ExitFuncSetter interface {
+	SetExitFunc(exitFunc func(code int))
+}
+
+// Flusher is implemented by the adapters that write the messages in the background. The suite flushes them before
+// reading their output.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// Config holds the settings the adapter under test must be created with.
+type Config struct {
+	// Writer is the destination of the messages.
+	Writer io.Writer
+	// Level is the minimum level of the written messages.
+	Level levels.Level
+	// ExitFunc must be called with the exit code instead of os.Exit after writing Fatal messages.
+	ExitFunc func(code int)
+}
+
+// NewFunc creates the adapter under test with the given config.
+type NewFunc func(config Config) Adapter
+
+// Run runs the conformance test suite on the adapters created by newAdapter, as subtests of t.
+func Run(t *testing.T, newAdapter NewFunc, opts ...Option) {
+	t.Helper()
+
+	s := newSuite(newAdapter, opts...)
+
+	t.Run("level filtering", s.testLevelFiltering)
+	t.Run("message", s.testMessage)
+	t.Run("timestamp", s.testTimestamp)
+	t.Run("fields", s.testFields)
+	t.Run("typed fields", s.testTypedFields)
+	t.Run("error and stack", s.testErrorAndStack)
+	t.Run("fields stack", s.testFieldsStack)
+	t.Run("set level", s.testSetLevel)
+	t.Run("set writer", s.testSetWriter)
+	t.Run("concurrency", s.testConcurrency)
+	t.Run("concurrent settings", s.testConcurrentSettings)
+	t.Run("fatal", s.testFatal)
+	t.Run("set exit func", s.testSetExitFunc)
+	t.Run("panic", s.testPanic)
+	t.Run("emit", s.testEmit)
+}
+
+// output is a concurrency safe buffer that collects the messages written by the adapter.
+type output struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.buf.Write(p)
+}
+
+func (o *output) String() string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.buf.String()
+}
+
+// messages flushes the adapter and decodes every line written on the output.
+func (o *output) messages(t *testing.T, adapter Adapter) []map[string]any {
+	t.Helper()
+
+	if flusher, ok := adapter.(Flusher); ok {
+		require.NoError(t, flusher.Flush(context.Background()), "failed to flush the adapter")
+	}
+
+	var messages []map[string]any
+
+	scanner := bufio.NewScanner(bytes.NewBufferString(o.String()))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		message := map[string]any{}
+		require.NoError(t, json.Unmarshal(line, &message), "the adapter wrote an invalid JSON line: %s", line)
+
+		messages = append(messages, message)
+	}
+
+	return messages
+}
@@ -0,0 +1,114 @@
+package adaptertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+// jsonAdapter is a minimal adapter used to verify the suite itself.
+type jsonAdapter struct {
+	mutex    sync.Mutex
+	writer   io.Writer
+	level    levels.Level
+	exitFunc func(code int)
+	config   logencoding.Config
+}
+
+func (a *jsonAdapter) Writer() io.Writer {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.writer
+}
+
+func (a *jsonAdapter) SetWriter(w io.Writer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.writer = w
+}
+
+func (a *jsonAdapter) Level() levels.Level {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.level
+}
+
+func (a *jsonAdapter) SetLevel(level levels.Level) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.level = level
+}
+
+func (a *jsonAdapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+func (a *jsonAdapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	message := fmt.Sprintf(msg, args...)
+
+	if adapterLevel := a.Level(); adapterLevel != levels.Disabled && level >= adapterLevel {
+		data := map[string]any{}
+
+		if logFields != nil {
+			for k, v := range logFields.Data() {
+				data[k] = v
+			}
+		}
+
+		data[a.config.TimeKey] = a.config.Time(t)
+		data[a.config.LevelKey] = a.config.Level(level)
+		data[a.config.MessageKey] = message
+
+		if err != nil {
+			data[a.config.ErrorKey] = err.Error()
+
+			if _, ok := data[a.config.StackKey]; !ok && level == levels.TraceLevel {
+				data[a.config.StackKey] = []string{"frame"}
+			}
+		}
+
+		line, _ := json.Marshal(data)
+
+		_, _ = a.Writer().Write(append(line, '\n'))
+	}
+
+	switch {
+	case level == levels.Fatal:
+		a.exitFunc(1)
+	case level == levels.Panic && err != nil:
+		panic(err)
+	case level == levels.Panic:
+		panic(message)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("should pass with the default encoding", func(t *testing.T) {
+		Run(t, func(config Config) Adapter {
+			return &jsonAdapter{writer: config.Writer, level: config.Level, exitFunc: config.ExitFunc, config: logencoding.New()}
+		})
+	})
+
+	t.Run("should pass with a custom encoding", func(t *testing.T) {
+		encodingConfig := logencoding.New(
+			logencoding.WithMessageKey("msg"),
+			logencoding.WithLevelCase(logencoding.Uppercase),
+			logencoding.WithTimeFormat(logencoding.TimeFormatUnixMs),
+		)
+
+		Run(t, func(config Config) Adapter {
+			return &jsonAdapter{writer: config.Writer, level: config.Level, exitFunc: config.ExitFunc, config: encodingConfig}
+		}, WithEncoding(encodingConfig))
+	})
+}
@@ -0,0 +1,20 @@
+package adaptertest
+
+import (
+	"github.com/danteay/golog/logencoding"
+)
+
+type options struct {
+	config logencoding.Config
+}
+
+// Option defines the signature for the options.
+type Option func(*options)
+
+// WithEncoding sets the key names, level casing and time format the adapter under test writes the messages with.
+// Defaults to logencoding.New().
+func WithEncoding(config logencoding.Config) Option {
+	return func(opts *options) {
+		opts.config = config
+	}
+}
@@ -0,0 +1,564 @@
+package adaptertest
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+	"github.com/danteay/golog/logencoding"
+)
+
+var logLevels = []levels.Level{levels.TraceLevel, levels.Debug, levels.Info, levels.Warn, levels.Error}
+
+type suite struct {
+	newAdapter NewFunc
+	config     logencoding.Config
+}
+
+func newSuite(newAdapter NewFunc, opts ...Option) *suite {
+	suiteOpts := options{config: logencoding.New()}
+
+	for _, opt := range opts {
+		opt(&suiteOpts)
+	}
+
+	return &suite{newAdapter: newAdapter, config: suiteOpts.config}
+}
+
+func (s *suite) new(level levels.Level, out *output, exitFunc func(code int)) Adapter {
+	if exitFunc == nil {
+		exitFunc = func(int) {}
+	}
+
+	return s.newAdapter(Config{Writer: out, Level: level, ExitFunc: exitFunc})
+}
+
+func (s *suite) testLevelFiltering(t *testing.T) {
+	for _, adapterLevel := range append([]levels.Level{levels.Disabled}, logLevels...) {
+		out := &output{}
+		adapter := s.new(adapterLevel, out, nil)
+
+		var expected []any
+
+		for _, level := range logLevels {
+			adapter.Log(level, nil, nil, "Test message")
+
+			if adapterLevel != levels.Disabled && level >= adapterLevel {
+				expected = append(expected, s.config.Level(level))
+			}
+		}
+
+		var written []any
+		for _, message := range out.messages(t, adapter) {
+			written = append(written, message[s.config.LevelKey])
+		}
+
+		assert.Equal(t, expected, written, "unexpected levels written with the %q adapter level", adapterLevel)
+	}
+}
+
+func (s *suite) testMessage(t *testing.T) {
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	adapter.Log(levels.Info, nil, nil, "Test %s %d", "message", 1)
+	adapter.Log(levels.Info, nil, fields.New(), "Test message without args")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, "Test message 1", messages[0][s.config.MessageKey])
+	assert.Equal(t, "Test message without args", messages[1][s.config.MessageKey])
+}
+
+func (s *suite) testTimestamp(t *testing.T) {
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	timed, ok := adapter.(TimedAdapter)
+	if !ok {
+		adapter.Log(levels.Info, nil, nil, "Test message")
+
+		messages := out.messages(t, adapter)
+		require.Len(t, messages, 1)
+		assert.Contains(t, messages[0], s.config.TimeKey)
+
+		return
+	}
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+	timed.LogAt(ts, levels.Info, nil, nil, "Test message")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 1)
+
+	expected := s.config.Time(ts)
+	if unix, isInt := expected.(int64); isInt {
+		expected = float64(unix)
+	}
+
+	assert.Equal(t, expected, messages[0][s.config.TimeKey])
+}
+
+type testStruct struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func (s *suite) testFields(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+	tests := map[string]struct {
+		value    any
+		expected any
+	}{
+		"string":       {value: "value", expected: "value"},
+		"empty_string": {value: "", expected: ""},
+		"int":          {value: 42, expected: float64(42)},
+		"int8":         {value: int8(-8), expected: float64(-8)},
+		"int64":        {value: int64(1) << 40, expected: float64(int64(1) << 40)},
+		"uint":         {value: uint(7), expected: float64(7)},
+		"uint64":       {value: uint64(64), expected: float64(64)},
+		"float32":      {value: float32(1.5), expected: 1.5},
+		"float64":      {value: 3.25, expected: 3.25},
+		"bool":         {value: true, expected: true},
+		"nil":          {value: nil, expected: nil},
+		"strings":      {value: []string{"a", "b"}, expected: []any{"a", "b"}},
+		"ints":         {value: []int{1, 2}, expected: []any{float64(1), float64(2)}},
+		"any_slice":    {value: []any{"a", 1, true}, expected: []any{"a", float64(1), true}},
+		"struct":       {value: testStruct{Name: "john", Age: 30}, expected: map[string]any{"name": "john", "age": float64(30)}},
+		"map": {
+			value:    map[string]any{"id": 1, "tags": []string{"x"}, "inner": map[string]any{"ok": true}},
+			expected: map[string]any{"id": float64(1), "tags": []any{"x"}, "inner": map[string]any{"ok": true}},
+		},
+	}
+
+	logFields := fields.New()
+	for key, test := range tests {
+		logFields.Set(key, test.value)
+	}
+
+	logFields.Set("created_at", ts)
+
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	adapter.Log(levels.Info, nil, logFields, "Test message")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 1)
+
+	for key, test := range tests {
+		if assert.Contains(t, messages[0], key, "field %q not written", key) {
+			assert.Equal(t, test.expected, messages[0][key], "unexpected value of the %q field", key)
+		}
+	}
+
+	assertTime(t, ts, messages[0]["created_at"], "created_at")
+}
+
+func (s *suite) testTypedFields(t *testing.T) {
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logFields := fields.New(
+		fields.String("string", "value"),
+		fields.Int64("int", -42),
+		fields.Uint64("uint", 42),
+		fields.Float64("float", 1.5),
+		fields.Bool("bool", true),
+		fields.Duration("duration", time.Second),
+		fields.Time("date", ts),
+		fields.Stringer("stringer", levels.Warn),
+		fields.Bytes("bytes", []byte("data")),
+		fields.Object("object", map[string]any{"key": "value"}),
+	)
+
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	adapter.Log(levels.Info, nil, logFields, "Test message")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 1)
+
+	expected := map[string]any{
+		"string":   "value",
+		"int":      float64(-42),
+		"uint":     float64(42),
+		"float":    1.5,
+		"bool":     true,
+		"stringer": "warn",
+		"bytes":    "data",
+		"object":   map[string]any{"key": "value"},
+	}
+
+	for key, value := range expected {
+		assert.Equal(t, value, messages[0][key], "unexpected value of the %q field", key)
+	}
+
+	assertTime(t, ts, messages[0]["date"], "date")
+	assertDuration(t, time.Second, messages[0]["duration"], "duration")
+}
+
+// assertDuration checks that the field value is the duration as a string parsed by time.ParseDuration, or as a number
+// of seconds, milliseconds, microseconds or nanoseconds, as every adapter writes durations with its own unit.
+func assertDuration(t *testing.T, expected time.Duration, value any, key string) {
+	t.Helper()
+
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if assert.NoError(t, err, "unexpected value of the %q field", key) {
+			assert.Equal(t, expected, parsed, "unexpected value of the %q field", key)
+		}
+	case float64:
+		units := []time.Duration{time.Second, time.Millisecond, time.Microsecond, time.Nanosecond}
+
+		for _, unit := range units {
+			if v == float64(expected)/float64(unit) {
+				return
+			}
+		}
+
+		assert.Fail(t, "unexpected value of the field", "the %q field is %v, not %s in any unit", key, v, expected)
+	default:
+		assert.Fail(t, "unexpected value of the field", "the %q field is %v, not a duration", key, value)
+	}
+}
+
+// assertTime checks that the field value is the time as an RFC 3339 string. Adapters that write times with a format
+// without fractional seconds, e.g. zerolog with its default time format, may truncate it to the second.
+func assertTime(t *testing.T, expected time.Time, value any, key string) {
+	t.Helper()
+
+	str, ok := value.(string)
+	if !assert.True(t, ok, "unexpected value of the %q field: %v", key, value) {
+		return
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, str)
+	if assert.NoError(t, err, "unexpected value of the %q field", key) {
+		assert.WithinDuration(t, expected, parsed, time.Second, "unexpected value of the %q field", key)
+		assert.False(t, parsed.After(expected), "unexpected value of the %q field: %s", key, str)
+	}
+}
+
+func (s *suite) testErrorAndStack(t *testing.T) {
+	out := &output{}
+	adapter := s.new(levels.TraceLevel, out, nil)
+	err := errors.New("test error")
+
+	adapter.Log(levels.Info, nil, nil, "Test message")
+	adapter.Log(levels.Error, err, nil, "Test message")
+	adapter.Log(levels.TraceLevel, err, nil, "Test message")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 3)
+
+	assert.NotContains(t, messages[0], s.config.ErrorKey, "error written on a message without error")
+	assert.NotContains(t, messages[0], s.config.StackKey, "stack written on a message without error")
+
+	assert.Equal(t, err.Error(), messages[1][s.config.ErrorKey])
+	assert.NotContains(t, messages[1], s.config.StackKey, "stack written on an error message without trace")
+
+	assert.Equal(t, err.Error(), messages[2][s.config.ErrorKey])
+	assert.NotEmpty(t, messages[2][s.config.StackKey], "stack not written on a trace message with error")
+}
+
+func (s *suite) testFieldsStack(t *testing.T) {
+	out := &output{}
+	adapter := s.new(levels.TraceLevel, out, nil)
+	stack := []string{"main.main", "/app/main.go:14"}
+
+	adapter.Log(levels.TraceLevel, errors.New("test error"), fields.New().Set(s.config.StackKey, stack), "Test message")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 1)
+
+	assert.Equal(t, []any{stack[0], stack[1]}, messages[0][s.config.StackKey])
+	assert.Equal(t, 1, strings.Count(out.String(), strconv.Quote(s.config.StackKey)+":"),
+		"stack written again on a message whose fields carry one: %s", out.String())
+}
+
+func (s *suite) testSetLevel(t *testing.T) {
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	assert.Equal(t, levels.Info, adapter.Level())
+
+	adapter.SetLevel(levels.Debug)
+	assert.Equal(t, levels.Debug, adapter.Level())
+
+	adapter.Log(levels.Debug, nil, nil, "debug message")
+
+	adapter.SetLevel(levels.Error)
+	assert.Equal(t, levels.Error, adapter.Level())
+
+	adapter.Log(levels.Warn, nil, nil, "warn message")
+	adapter.Log(levels.Error, nil, nil, "error message")
+
+	var written []any
+	for _, message := range out.messages(t, adapter) {
+		written = append(written, message[s.config.MessageKey])
+	}
+
+	assert.Equal(t, []any{"debug message", "error message"}, written)
+}
+
+func (s *suite) testSetWriter(t *testing.T) {
+	first, second := &output{}, &output{}
+	adapter := s.new(levels.Info, first, nil)
+
+	adapter.Log(levels.Info, nil, nil, "first message")
+	adapter.SetWriter(second)
+	adapter.Log(levels.Info, nil, nil, "second message")
+
+	assert.True(t, adapter.Writer() == second, "Writer does not return the writer set with SetWriter")
+
+	firstMessages := first.messages(t, adapter)
+	secondMessages := second.messages(t, adapter)
+
+	require.Len(t, firstMessages, 1)
+	require.Len(t, secondMessages, 1)
+
+	assert.Equal(t, "first message", firstMessages[0][s.config.MessageKey])
+	assert.Equal(t, "second message", secondMessages[0][s.config.MessageKey])
+}
+
+func (s *suite) testConcurrency(t *testing.T) {
+	const (
+		goroutines = 10
+		messages   = 100
+	)
+
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+	logFields := fields.New().Set("shared", "value")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < messages; j++ {
+				adapter.Log(levels.Info, nil, logFields, "concurrent message %d", i)
+				_ = adapter.Level()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	written := out.messages(t, adapter)
+	require.Len(t, written, goroutines*messages)
+
+	for _, message := range written {
+		assert.Equal(t, "value", message["shared"])
+	}
+}
+
+func (s *suite) testConcurrentSettings(t *testing.T) {
+	const (
+		goroutines = 10
+		messages   = 100
+	)
+
+	out, other := &output{}, &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	var (
+		wg      sync.WaitGroup
+		settled sync.WaitGroup
+	)
+
+	done := make(chan struct{})
+
+	settled.Add(1)
+
+	go func() {
+		defer settled.Done()
+
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				adapter.SetLevel(levels.Info)
+				adapter.SetWriter(out)
+
+				return
+			default:
+			}
+
+			if i%2 == 0 {
+				adapter.SetLevel(levels.Debug)
+				adapter.SetWriter(other)
+			} else {
+				adapter.SetLevel(levels.Info)
+				adapter.SetWriter(out)
+			}
+
+			_ = adapter.Writer()
+		}
+	}()
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < messages; j++ {
+				adapter.Log(levels.Info, nil, nil, "concurrent message %d", i)
+				_ = adapter.Level()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(done)
+	settled.Wait()
+
+	written := append(out.messages(t, adapter), other.messages(t, adapter)...)
+	assert.Len(t, written, goroutines*messages, "messages were lost while changing the level and writer")
+
+	assert.Equal(t, levels.Info, adapter.Level())
+}
+
+func (s *suite) testFatal(t *testing.T) {
+	var (
+		mutex sync.Mutex
+		codes []int
+	)
+
+	out := &output{}
+	adapter := s.new(levels.Info, out, func(code int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		codes = append(codes, code)
+	})
+
+	adapter.Log(levels.Fatal, nil, nil, "Test message")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 1)
+
+	assert.Equal(t, s.config.Level(levels.Fatal), messages[0][s.config.LevelKey])
+	assert.Equal(t, "Test message", messages[0][s.config.MessageKey])
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assert.Equal(t, []int{1}, codes, "the exit function must be called once with code 1")
+}
+
+func (s *suite) testSetExitFunc(t *testing.T) {
+	const messages = 100
+
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	setter, ok := adapter.(ExitFuncSetter)
+	if !ok {
+		t.Skip("the adapter does not implement SetExitFunc")
+	}
+
+	var (
+		wg    sync.WaitGroup
+		calls atomic.Int32
+	)
+
+	exitFunc := func(int) {
+		calls.Add(1)
+	}
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < messages; i++ {
+			setter.SetExitFunc(exitFunc)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < messages; i++ {
+			adapter.Log(levels.Fatal, nil, nil, "Test message")
+		}
+	}()
+
+	wg.Wait()
+
+	before := calls.Load()
+
+	adapter.Log(levels.Fatal, nil, nil, "Test message")
+
+	assert.Equal(t, before+1, calls.Load(), "the replaced exit function must be called after writing Fatal messages")
+	assert.Len(t, out.messages(t, adapter), messages+1)
+}
+
+func (s *suite) testPanic(t *testing.T) {
+	out := &output{}
+	adapter := s.new(levels.Info, out, nil)
+
+	err := errors.New("test error")
+
+	assert.PanicsWithValue(t, "Test message", func() {
+		adapter.Log(levels.Panic, nil, nil, "Test %s", "message")
+	}, "the adapter must panic with the message when no error is set")
+
+	assert.PanicsWithValue(t, err, func() {
+		adapter.Log(levels.Panic, err, nil, "Test %s", "message")
+	}, "the adapter must panic with the error when it is set")
+
+	messages := out.messages(t, adapter)
+	require.Len(t, messages, 2)
+
+	for _, message := range messages {
+		assert.Equal(t, s.config.Level(levels.Panic), message[s.config.LevelKey])
+		assert.Equal(t, "Test message", message[s.config.MessageKey])
+	}
+
+	assert.Equal(t, err.Error(), messages[1][s.config.ErrorKey])
+}
+
+func (s *suite) testEmit(t *testing.T) {
+	out := &output{}
+	adapter := s.new(levels.Info, out, func(int) {
+		t.Error("the exit function was called by Emit")
+	})
+
+	emitter, ok := adapter.(Emitter)
+	if !ok {
+		t.Skip("the adapter does not implement Emitter")
+	}
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+	assert.NotPanics(t, func() {
+		emitter.Emit(ts, levels.Fatal, nil, nil, "fatal %s", "message")
+		emitter.Emit(ts, levels.Panic, nil, nil, "panic %s", "message")
+	})
+
+	var written []any
+	for _, message := range out.messages(t, adapter) {
+		written = append(written, message[s.config.MessageKey])
+	}
+
+	assert.Equal(t, []any{"fatal message", "panic message"}, written)
+}
@@ -0,0 +1,49 @@
+package golog
+
+import (
+	"log/slog"
+	"runtime"
+)
+
+// SourceField is the field name used to print the location of the log call when the caller is captured with
+// WithCaller.
+var SourceField = "source"
+
+// callerDepth is the number of frames between runtime.Callers and the caller of the Logger log methods: callerPC,
+// logf and the exported log method.
+const callerDepth = 4
+
+// Source is the location of the code that logged a message.
+type Source struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// LogValue returns the source as a group, so slog text handlers write each value as a separate attribute.
+func (s Source) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("function", s.Function),
+		slog.String("file", s.File),
+		slog.Int("line", s.Line),
+	)
+}
+
+// callerPC returns the program counter of the caller skip frames above runtime.Callers, or zero if the stack is not
+// that deep.
+func callerPC(skip int) uintptr {
+	var pcs [1]uintptr
+
+	if runtime.Callers(skip, pcs[:]) == 0 {
+		return 0
+	}
+
+	return pcs[0]
+}
+
+// newSource returns the function, file and line of the program counter.
+func newSource(pc uintptr) Source {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	return Source{Function: frame.Function, File: frame.File, Line: frame.Line}
+}
@@ -0,0 +1,129 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	stdslog "log/slog"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/levels"
+)
+
+// logThroughWrapper logs through a helper function, as the applications that wrap the logger do.
+func logThroughWrapper(logger *Logger, msg string) {
+	logger.CallerSkip(1).Info(msg)
+}
+
+// callerLine returns the line of the code that called it.
+func callerLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func decodeMessage(t *testing.T, output *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	res := map[string]any{}
+
+	if errUnmarshal := json.Unmarshal(output.Bytes(), &res); errUnmarshal != nil {
+		t.Fatal(errUnmarshal)
+	}
+
+	output.Reset()
+
+	return res
+}
+
+func TestLoggerCaller(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	function := "github.com/danteay/golog.TestLoggerCaller.func"
+
+	t.Run("should write the location of the log call", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))), WithCaller())
+
+		tests := map[string]func() int{
+			"Info": func() int {
+				logger.Info("Test message")
+				return callerLine() - 1
+			},
+			"Log": func() int {
+				logger.Log(levels.Warn, "Test message")
+				return callerLine() - 1
+			},
+			"child logger": func() int {
+				logger.Field("key", "value").Error("Test message")
+				return callerLine() - 1
+			},
+		}
+
+		for name, test := range tests {
+			line := test()
+			source, _ := decodeMessage(t, &logOutput)[SourceField].(map[string]any)
+
+			assert.Equal(t, file, source["file"], name)
+			assert.Equal(t, float64(line), source["line"], name)
+			assert.Contains(t, source["function"], function, name)
+		}
+	})
+
+	t.Run("should skip the frames of wrappers", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))), WithCaller())
+
+		logThroughWrapper(logger, "Test message")
+		line := callerLine() - 1
+
+		source, _ := decodeMessage(t, &logOutput)[SourceField].(map[string]any)
+		assert.Equal(t, float64(line), source["line"])
+
+		logger = New(WithAdapter(slog.New(slog.WithWriter(&logOutput))), WithCaller(), WithCallerSkip(1))
+
+		func() {
+			logger.Info("Test message")
+		}()
+		line = callerLine() - 1
+
+		source, _ = decodeMessage(t, &logOutput)[SourceField].(map[string]any)
+		assert.Equal(t, float64(line), source["line"])
+	})
+
+	t.Run("should use the location of slog records", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))), WithCaller())
+
+		stdslog.New(NewSlogHandler(logger)).Info("Test message")
+		line := callerLine() - 1
+
+		source, _ := decodeMessage(t, &logOutput)[SourceField].(map[string]any)
+		assert.Equal(t, file, source["file"])
+		assert.Equal(t, float64(line), source["line"])
+	})
+
+	t.Run("should not write the location when it is disabled", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+		logger.Info("Test message")
+
+		assert.NotContains(t, decodeMessage(t, &logOutput), SourceField)
+	})
+
+	t.Run("should not write the location of lines written by a LineWriter", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))), WithCaller())
+
+		_, _ = NewLineWriter(logger).Write([]byte("Test message\n"))
+
+		assert.NotContains(t, decodeMessage(t, &logOutput), SourceField)
+	})
+}
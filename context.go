@@ -0,0 +1,101 @@
+package golog
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"github.com/danteay/golog/internal/contextfields"
+)
+
+type loggerContextKey struct{}
+
+var (
+	defaultLogger *Logger
+	defaultMutex  *sync.RWMutex
+)
+
+func init() {
+	defaultLogger = New()
+	defaultMutex = &sync.RWMutex{}
+}
+
+// SetDefault sets the logger returned by FromContext when no logger is stored on the context.
+func SetDefault(logger *Logger) {
+	if logger == nil {
+		return
+	}
+
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+
+	defaultLogger = logger
+}
+
+// Default returns the default logger instance.
+func Default() *Logger {
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+
+	return defaultLogger
+}
+
+// WithContext returns a copy of ctx that carries the provided logger, including its accumulated fields.
+func WithContext(ctx context.Context, logger *Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if logger == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger stored on ctx, or the default logger if there is none. The returned logger uses
+// ctx as its execution context, so context fields stored for that execution are added to every log message.
+//
+// To add fields for a child scope without touching the parent's logger, derive a child logger and store it on a
+// child context:
+//
+//	ctx = golog.WithContext(ctx, golog.FromContext(ctx).With(map[string]any{"key": "value"}))
+func FromContext(ctx context.Context) *Logger {
+	if ctx == nil {
+		return Default()
+	}
+
+	logger, ok := ctx.Value(loggerContextKey{}).(*Logger)
+	if !ok || logger == nil {
+		logger = Default()
+	}
+
+	return logger.SetContext(ctx)
+}
+
+// ExecutionIDField is the field name used to print the execution id stored with NewExecution.
+var ExecutionIDField = "execution_id"
+
+// NewExecution returns a copy of ctx identified by the provided execution id, e.g. a request id. If id is empty a
+// random one is generated. Context fields set with a logger that uses the returned context are grouped under this
+// execution, and the id is added to every log message as the ExecutionIDField field.
+func NewExecution(ctx context.Context, id string) context.Context {
+	if id == "" {
+		id = newExecutionID()
+	}
+
+	return contextfields.WithExecution(ctx, id)
+}
+
+// ExecutionID returns the execution id stored on ctx with NewExecution.
+func ExecutionID(ctx context.Context) (string, bool) {
+	return contextfields.ExecutionID(ctx)
+}
+
+func newExecutionID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,169 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/levels"
+)
+
+func TestWithContext(t *testing.T) {
+	t.Run("should store logger on context", func(t *testing.T) {
+		logger := New().With(map[string]any{"key1": "value1"})
+
+		ctx := WithContext(context.Background(), logger)
+		fromCtx := FromContext(ctx)
+
+		assert.Equal(t, ctx, fromCtx.ctx)
+		assert.Same(t, logger.logger, fromCtx.logger)
+		assert.Equal(t, logger.fields.Data(), fromCtx.fields.Data())
+	})
+
+	t.Run("should ignore nil logger", func(t *testing.T) {
+		ctx := context.Background()
+
+		assert.Equal(t, ctx, WithContext(ctx, nil))
+	})
+
+	t.Run("should not modify parent context logger", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		parent := WithContext(context.Background(), New(WithAdapter(adapter)).With(map[string]any{"key1": "value1"}))
+		child := WithContext(parent, FromContext(parent).With(map[string]any{"key2": 42}))
+
+		FromContext(child).Info("child message")
+
+		res := testMsg{}
+
+		if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, "value1", res.Key1)
+		assert.Equal(t, 42, res.Key2)
+
+		logOutput.Reset()
+
+		FromContext(parent).Info("parent message")
+
+		res = testMsg{}
+
+		if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, "value1", res.Key1)
+		assert.Equal(t, 0, res.Key2)
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("should fallback to default logger", func(t *testing.T) {
+		defaultLog := New().With(map[string]any{"default": true})
+
+		prev := Default()
+		SetDefault(defaultLog)
+		defer SetDefault(prev)
+
+		ctx := context.Background()
+		logger := FromContext(ctx)
+
+		assert.Equal(t, ctx, logger.ctx)
+		assert.Equal(t, defaultLog.fields.Data(), logger.fields.Data())
+	})
+
+	t.Run("should fallback to default logger on nil context", func(t *testing.T) {
+		assert.Same(t, Default(), FromContext(nil))
+	})
+}
+
+func TestSetDefault(t *testing.T) {
+	prev := Default()
+	defer SetDefault(prev)
+
+	logger := New()
+	SetDefault(logger)
+	assert.Same(t, logger, Default())
+
+	SetDefault(nil)
+	assert.Same(t, logger, Default())
+}
+
+func TestNewExecution(t *testing.T) {
+	t.Run("should use provided execution id", func(t *testing.T) {
+		ctx := NewExecution(context.Background(), "some-exec-id")
+
+		id, ok := ExecutionID(ctx)
+
+		assert.True(t, ok)
+		assert.Equal(t, "some-exec-id", id)
+	})
+
+	t.Run("should generate execution id", func(t *testing.T) {
+		id1, ok1 := ExecutionID(NewExecution(context.Background(), ""))
+		id2, ok2 := ExecutionID(NewExecution(context.Background(), ""))
+
+		assert.True(t, ok1)
+		assert.True(t, ok2)
+		assert.Len(t, id1, 32)
+		assert.NotEqual(t, id1, id2)
+	})
+
+	t.Run("should log execution id and context fields", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter)).SetContext(NewExecution(context.Background(), "some-exec-id"))
+		defer logger.FlushContextFields()
+
+		logger.SetContextFields(map[string]any{"ctx_key": "some context val"})
+		logger.Info("Test message")
+
+		res := map[string]any{}
+
+		if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, "some-exec-id", res["execution_id"])
+		assert.Equal(t, "some context val", res["ctx_key"])
+
+		logOutput.Reset()
+
+		New(WithAdapter(adapter)).SetContext(NewExecution(context.Background(), "other-exec-id")).Info("Test message")
+
+		res = map[string]any{}
+
+		if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, "other-exec-id", res["execution_id"])
+		assert.Nil(t, res["ctx_key"])
+	})
+
+	t.Run("should log execution id with the configured field name", func(t *testing.T) {
+		ExecutionIDField = "request_id"
+		defer func() { ExecutionIDField = "execution_id" }()
+
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput))
+		New(WithAdapter(adapter)).SetContext(NewExecution(context.Background(), "some-exec-id")).Info("Test message")
+
+		res := map[string]any{}
+
+		if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, "some-exec-id", res["request_id"])
+		assert.Nil(t, res["execution_id"])
+	})
+}
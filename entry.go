@@ -0,0 +1,25 @@
+package golog
+
+import (
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+// Entry is a log message that is about to be written by the adapter.
+type Entry struct {
+	Time    time.Time
+	Level   levels.Level
+	Err     error
+	Fields  *fields.Fields
+	Message string
+	// Format and Args are the format string and the arguments the message was formatted with. They are empty for the
+	// messages that were not formatted by the Logger, e.g. the lines of a LineWriter or the records of a SlogHandler.
+	Format string
+	Args   []any
+}
+
+// Hook runs on every log entry before it reaches the adapter. It can modify the entry, or drop it by returning
+// false, in which case the remaining hooks are not executed.
+type Hook func(entry *Entry) bool
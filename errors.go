@@ -0,0 +1,220 @@
+package golog
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/danteay/golog/fields"
+)
+
+// StackField is the field name used to print the stack trace of the logged errors. The built-in adapters do not add
+// their own stack trace to the messages that carry one on their stack key or on the default "stack" key, so when it
+// is renamed, the adapter stack key should be set to the same name, e.g. with logencoding.WithStackKey.
+var StackField = "stack"
+
+// ErrorChainField is the field name used to print the logged errors with the errors they wrap.
+var ErrorChainField = "error_chain"
+
+// ErrorFieldsPrefix is prepended to the keys of the fields carried by the logged errors.
+var ErrorFieldsPrefix = "error."
+
+const (
+	// maxStackDepth is the maximum number of frames of the captured stack traces.
+	maxStackDepth = 64
+	// maxErrorDepth is the maximum depth of the wrapped errors included on an ErrorInfo.
+	maxErrorDepth = 32
+)
+
+// Frame is a function call of a stack trace.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// ErrorInfo is the structured representation of an error, with the message and type of the error and of the errors
+// it wraps. Errors wrapped with fmt.Errorf and %w have one cause, and errors created with errors.Join have one cause
+// for each joined error.
+type ErrorInfo struct {
+	Message string      `json:"message"`
+	Type    string      `json:"type"`
+	Causes  []ErrorInfo `json:"causes,omitempty"`
+}
+
+// FrameTracer is implemented by errors that carry the stack trace of where they were created. It is used to write
+// the stack trace of custom errors, along with the errors created with github.com/pkg/errors, that have a StackTrace
+// method, and with github.com/go-errors/errors, that have a Callers method.
+type FrameTracer interface {
+	StackFrames() []Frame
+}
+
+// FieldsError is implemented by errors that carry structured data, e.g. an error code or an HTTP status, that is
+// logged along with the error message. Errors can implement slog.LogValuer instead, returning a group value.
+type FieldsError interface {
+	LogFields() map[string]any
+}
+
+type callersTracer interface {
+	Callers() []uintptr
+}
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+// NewErrorInfo returns the structured representation of the error and the errors it wraps.
+func NewErrorInfo(err error) ErrorInfo {
+	return newErrorInfo(err, 0)
+}
+
+func newErrorInfo(err error, depth int) ErrorInfo {
+	info := ErrorInfo{Message: err.Error(), Type: fmt.Sprintf("%T", err)}
+
+	if depth >= maxErrorDepth {
+		return info
+	}
+
+	for _, cause := range unwrap(err) {
+		info.Causes = append(info.Causes, newErrorInfo(cause, depth+1))
+	}
+
+	return info
+}
+
+// ErrorFields returns the fields carried by the error and by the errors it wraps, that implement FieldsError or
+// slog.LogValuer, with ErrorFieldsPrefix prepended to their keys. Nested slog groups are flattened with dotted keys.
+// When several errors of the chain carry the same field, the value of the outermost error is kept.
+func ErrorFields(err error) *fields.Fields {
+	errFields := fields.New()
+	addErrorFields(errFields, err, 0)
+
+	return errFields
+}
+
+func addErrorFields(errFields *fields.Fields, err error, depth int) {
+	if depth < maxErrorDepth {
+		causes := unwrap(err)
+
+		for i := len(causes) - 1; i >= 0; i-- {
+			addErrorFields(errFields, causes[i], depth+1)
+		}
+	}
+
+	switch fielder := err.(type) {
+	case FieldsError:
+		for key, value := range fielder.LogFields() {
+			errFields.Set(ErrorFieldsPrefix+key, value)
+		}
+	case slog.LogValuer:
+		if value := fielder.LogValue().Resolve(); value.Kind() == slog.KindGroup {
+			_ = addAttr(errFields, ErrorFieldsPrefix, slog.Attr{Value: value})
+		}
+	}
+}
+
+// ErrorStack returns the stack trace of where the error was created, taken from the deepest error of its chain that
+// carries one. It returns false if none of the errors carry a stack trace.
+func ErrorStack(err error) ([]Frame, bool) {
+	frames := findErrorStack(err, 0)
+
+	return frames, frames != nil
+}
+
+func findErrorStack(err error, depth int) []Frame {
+	if depth < maxErrorDepth {
+		for _, cause := range unwrap(err) {
+			if frames := findErrorStack(cause, depth+1); frames != nil {
+				return frames
+			}
+		}
+	}
+
+	return errorFrames(err)
+}
+
+// errorFrames returns the stack trace carried by the error itself, without looking at the errors it wraps.
+func errorFrames(err error) []Frame {
+	switch tracer := err.(type) {
+	case FrameTracer:
+		return tracer.StackFrames()
+	case callersTracer:
+		return framesOf(tracer.Callers())
+	case stackTracer:
+		return framesOf(stackTracePCs(tracer.StackTrace()))
+	}
+
+	return nil
+}
+
+// stackTracePCs returns the program counters of the stack trace of an error created with github.com/pkg/errors.
+func stackTracePCs(trace errors.StackTrace) []uintptr {
+	pcs := make([]uintptr, len(trace))
+	for i, frame := range trace {
+		pcs[i] = uintptr(frame)
+	}
+
+	return pcs
+}
+
+// callStack returns the stack trace of the log call, without the frames of the Logger itself.
+func callStack() []Frame {
+	var pcs [maxStackDepth]uintptr
+
+	frames := framesOf(pcs[:runtime.Callers(2, pcs[:])])
+
+	for len(frames) > 1 && isLoggerFrame(frames[0]) {
+		frames = frames[1:]
+	}
+
+	return frames
+}
+
+// isLoggerFrame reports whether the frame is a method of the golog types, or a function of the slog package calling
+// a SlogHandler.
+func isLoggerFrame(frame Frame) bool {
+	return strings.HasPrefix(frame.Function, "github.com/danteay/golog.(*") || strings.HasPrefix(frame.Function, "log/slog.")
+}
+
+func framesOf(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	frames := make([]Frame, 0, len(pcs))
+	callers := runtime.CallersFrames(pcs)
+
+	for {
+		frame, more := callers.Next()
+		frames = append(frames, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+
+		if !more {
+			return frames
+		}
+	}
+}
+
+// unwrap returns the errors wrapped by err.
+func unwrap(err error) []error {
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := wrapper.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	case interface{ Unwrap() []error }:
+		var causes []error
+
+		for _, cause := range wrapper.Unwrap() {
+			if cause != nil {
+				causes = append(causes, cause)
+			}
+		}
+
+		return causes
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	stdslog "log/slog"
+	"runtime"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/danteay/golog/adapters/slog"
+)
+
+type framesError struct {
+	frames []Frame
+}
+
+func (e framesError) Error() string { return "frames error" }
+
+func (e framesError) StackFrames() []Frame { return e.frames }
+
+type callersError struct {
+	pcs []uintptr
+}
+
+func (e *callersError) Error() string { return "callers error" }
+
+func (e *callersError) Callers() []uintptr { return e.pcs }
+
+type fieldsError struct {
+	fields map[string]any
+}
+
+func (e fieldsError) Error() string { return "fields error" }
+
+func (e fieldsError) LogFields() map[string]any { return e.fields }
+
+type valuerError struct {
+	code string
+}
+
+func (e valuerError) Error() string { return "valuer error" }
+
+func (e valuerError) LogValue() stdslog.Value {
+	return stdslog.GroupValue(
+		stdslog.String("code", e.code),
+		stdslog.Group("http", stdslog.Int("status", 404)),
+	)
+}
+
+type stringValuerError struct{}
+
+func (e stringValuerError) Error() string { return "string valuer error" }
+
+func (e stringValuerError) LogValue() stdslog.Value { return stdslog.StringValue("value") }
+
+type wrappedFieldsError struct {
+	fieldsError
+	cause error
+}
+
+func (e *wrappedFieldsError) Unwrap() error { return e.cause }
+
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	return pcs[:runtime.Callers(2, pcs)]
+}
+
+func newPkgError() error {
+	return pkgerrors.New("pkg error")
+}
+
+func TestNewErrorInfo(t *testing.T) {
+	errFirst := errors.New("first error")
+	errSecond := &callersError{}
+
+	tests := map[string]struct {
+		err      error
+		expected ErrorInfo
+	}{
+		"simple error": {
+			err:      errFirst,
+			expected: ErrorInfo{Message: "first error", Type: "*errors.errorString"},
+		},
+		"wrapped error": {
+			err: fmt.Errorf("wrapped: %w", errFirst),
+			expected: ErrorInfo{
+				Message: "wrapped: first error",
+				Type:    "*fmt.wrapError",
+				Causes:  []ErrorInfo{{Message: "first error", Type: "*errors.errorString"}},
+			},
+		},
+		"joined errors": {
+			err: fmt.Errorf("wrapped: %w", errors.Join(errFirst, errSecond)),
+			expected: ErrorInfo{
+				Message: "wrapped: first error\ncallers error",
+				Type:    "*fmt.wrapError",
+				Causes: []ErrorInfo{{
+					Message: "first error\ncallers error",
+					Type:    "*errors.joinError",
+					Causes: []ErrorInfo{
+						{Message: "first error", Type: "*errors.errorString"},
+						{Message: "callers error", Type: "*golog.callersError"},
+					},
+				}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewErrorInfo(test.err))
+		})
+	}
+}
+
+func TestErrorFields(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected map[string]any
+	}{
+		"error without fields": {
+			err:      errors.New("first error"),
+			expected: map[string]any{},
+		},
+		"fields error": {
+			err:      fieldsError{fields: map[string]any{"code": "E42", "retryable": true}},
+			expected: map[string]any{"error.code": "E42", "error.retryable": true},
+		},
+		"log valuer error": {
+			err:      valuerError{code: "E42"},
+			expected: map[string]any{"error.code": "E42", "error.http.status": int64(404)},
+		},
+		"log valuer error without group": {
+			err:      stringValuerError{},
+			expected: map[string]any{},
+		},
+		"chain of errors": {
+			err: fmt.Errorf("wrapped: %w", errors.Join(
+				fieldsError{fields: map[string]any{"retryable": false}},
+				valuerError{code: "E42"},
+			)),
+			expected: map[string]any{"error.code": "E42", "error.http.status": int64(404), "error.retryable": false},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ErrorFields(test.err).Data())
+		})
+	}
+
+	t.Run("should keep the fields of the outermost error", func(t *testing.T) {
+		err := &wrappedFieldsError{
+			fieldsError: fieldsError{fields: map[string]any{"code": "outer"}},
+			cause:       fieldsError{fields: map[string]any{"code": "inner", "op": "load"}},
+		}
+
+		assert.Equal(t, map[string]any{"error.code": "outer", "error.op": "load"}, ErrorFields(err).Data())
+	})
+}
+
+func TestErrorStack(t *testing.T) {
+	t.Run("should return the frames of errors with stack frames", func(t *testing.T) {
+		frames := []Frame{{Function: "main.main", File: "main.go", Line: 10}}
+
+		stack, ok := ErrorStack(fmt.Errorf("wrapped: %w", framesError{frames: frames}))
+
+		assert.True(t, ok)
+		assert.Equal(t, frames, stack)
+	})
+
+	t.Run("should parse the program counters of errors with callers", func(t *testing.T) {
+		stack, ok := ErrorStack(&callersError{pcs: callers()})
+
+		require.True(t, ok)
+		assert.Equal(t, "github.com/danteay/golog.TestErrorStack.func2", stack[0].Function)
+	})
+
+	t.Run("should parse the stack trace of pkg/errors like errors", func(t *testing.T) {
+		stack, ok := ErrorStack(fmt.Errorf("wrapped: %w", newPkgError()))
+
+		require.True(t, ok)
+		assert.Equal(t, "github.com/danteay/golog.newPkgError", stack[0].Function)
+	})
+
+	t.Run("should return the stack of the first joined error that carries one", func(t *testing.T) {
+		frames := []Frame{{Function: "main.main", File: "main.go", Line: 10}}
+
+		stack, ok := ErrorStack(errors.Join(errors.New("first error"), &callersError{pcs: callers()}, framesError{frames: frames}))
+
+		require.True(t, ok)
+		assert.Equal(t, "github.com/danteay/golog.TestErrorStack.func4", stack[0].Function)
+	})
+
+	t.Run("should report errors without stack trace", func(t *testing.T) {
+		stack, ok := ErrorStack(fmt.Errorf("wrapped: %w", errors.New("first error")))
+
+		assert.False(t, ok)
+		assert.Nil(t, stack)
+	})
+}
+
+func TestLoggerErrorFields(t *testing.T) {
+	t.Run("should write the error chain and the origin stack trace", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+		logger.Err(fmt.Errorf("wrapped: %w", newPkgError())).Error("Test message")
+
+		res := decodeMessage(t, &logOutput)
+
+		assert.Equal(t, map[string]any{
+			"message": "wrapped: pkg error",
+			"type":    "*fmt.wrapError",
+			"causes":  []any{map[string]any{"message": "pkg error", "type": "*errors.fundamental"}},
+		}, res[ErrorChainField])
+
+		stack, _ := res[StackField].([]any)
+		require.NotEmpty(t, stack)
+		assert.Equal(t, "github.com/danteay/golog.newPkgError", stack[0].(map[string]any)["function"])
+	})
+
+	t.Run("should write the stack trace of the log call", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+		logger.Err(errors.New("test error")).Error("Test message")
+
+		res := decodeMessage(t, &logOutput)
+
+		stack, _ := res[StackField].([]any)
+		require.NotEmpty(t, stack)
+		assert.Equal(t, "github.com/danteay/golog.TestLoggerErrorFields.func2", stack[0].(map[string]any)["function"])
+	})
+}
+
+func TestLoggerErrorFieldsOfErrors(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+	err := fmt.Errorf("wrapped: %w", fieldsError{fields: map[string]any{"code": "E42", "status": 503}})
+
+	logger.Err(err).Field("error.status", 500).Error("Test message")
+
+	res := decodeMessage(t, &logOutput)
+
+	assert.Equal(t, "E42", res["error.code"])
+	assert.Equal(t, float64(500), res["error.status"], "the logger fields must override the error fields")
+}
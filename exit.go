@@ -0,0 +1,101 @@
+package golog
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+// DefaultExitCode is the exit code used after writing Fatal messages when no other code is configured.
+const DefaultExitCode = 1
+
+// exitFlushTimeout is the maximum time the Logger waits for the adapter to flush its buffered messages before
+// exiting or panicking.
+const exitFlushTimeout = 5 * time.Second
+
+// PanicFunc is called after writing a Panic message with the formatted message and the error of the logger, which
+// is nil when no error was set with Err.
+type PanicFunc func(msg string, err error)
+
+// Emitter is implemented by adapters that exit the program after writing Fatal messages or panic after writing Panic
+// messages. Emit writes the message without terminating, so the Logger writes every message with it and handles Fatal
+// and Panic messages with its own exit handler, without changing the adapter, that may be shared with code that uses
+// it directly. Adapters that don't implement it terminate on their own before the shutdown hooks run.
+type Emitter interface {
+	Emit(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+// Flusher is implemented by adapters that write the messages in the background. The Logger flushes them before
+// exiting or panicking, so no buffered message is lost.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// DefaultPanicFunc panics with the error when it is set, or with the message otherwise.
+func DefaultPanicFunc(msg string, err error) {
+	if err != nil {
+		panic(err)
+	}
+
+	panic(msg)
+}
+
+// exitHandler holds the behaviour of the Logger after writing Fatal and Panic messages.
+type exitHandler struct {
+	exitFunc      func(code int)
+	exitCode      int
+	shutdownHooks []func()
+	panicFunc     PanicFunc
+}
+
+func newExitHandler(opts options) *exitHandler {
+	handler := &exitHandler{
+		exitFunc:      opts.exitFunc,
+		exitCode:      opts.exitCode,
+		shutdownHooks: opts.shutdownHooks,
+		panicFunc:     opts.panicFunc,
+	}
+
+	if handler.exitFunc == nil {
+		handler.exitFunc = os.Exit
+	}
+
+	if handler.panicFunc == nil {
+		handler.panicFunc = DefaultPanicFunc
+	}
+
+	return handler
+}
+
+// terminate exits the program after a Fatal entry, running the shutdown hooks and flushing the adapter first, or
+// panics after a Panic entry once the adapter is flushed. Entries with any other level are ignored.
+func (l *Logger) terminate(entry *Entry) {
+	switch entry.Level {
+	case levels.Fatal:
+		for _, hook := range l.exit.shutdownHooks {
+			hook()
+		}
+
+		l.flush()
+		l.exit.exitFunc(l.exit.exitCode)
+	case levels.Panic:
+		l.flush()
+		l.exit.panicFunc(entry.Message, entry.Err)
+	}
+}
+
+// flush waits until the adapter writes its buffered messages, if it writes them in the background.
+func (l *Logger) flush() {
+	flusher, ok := l.logger.(Flusher)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exitFlushTimeout)
+	defer cancel()
+
+	_ = flusher.Flush(ctx)
+}
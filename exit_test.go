@@ -0,0 +1,154 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/levels"
+)
+
+type flushAdapter struct {
+	*slog.Adapter
+	calls *[]string
+}
+
+func (a flushAdapter) Flush(_ context.Context) error {
+	*a.calls = append(*a.calls, "flush")
+	return nil
+}
+
+func newFlushAdapter(t *testing.T, w *bytes.Buffer, calls *[]string) flushAdapter {
+	adapter := slog.New(slog.WithWriter(w), slog.WithExitFunc(func(int) {
+		t.Error("the adapter exit function was called")
+	}))
+
+	return flushAdapter{Adapter: adapter, calls: calls}
+}
+
+func TestLoggerFatal(t *testing.T) {
+	t.Run("should run the shutdown hooks, flush and exit", func(t *testing.T) {
+		var (
+			logOutput bytes.Buffer
+			calls     []string
+		)
+
+		logger := New(
+			WithAdapter(newFlushAdapter(t, &logOutput, &calls)),
+			WithShutdownHooks(func() { calls = append(calls, "hook 1") }),
+			WithShutdownHooks(func() { calls = append(calls, "hook 2") }),
+			WithExitCode(3),
+			WithExitFunc(func(code int) {
+				assert.Contains(t, logOutput.String(), "Test message")
+				calls = append(calls, "exit")
+				assert.Equal(t, 3, code)
+			}),
+		)
+
+		logger.Fatal("Test %s", "message")
+
+		assert.Equal(t, []string{"hook 1", "hook 2", "flush", "exit"}, calls)
+	})
+
+	t.Run("should exit with the default code", func(t *testing.T) {
+		var codes []int
+
+		logger := New(
+			WithAdapter(slog.New(slog.WithWriter(&bytes.Buffer{}))),
+			WithExitFunc(func(code int) { codes = append(codes, code) }),
+		)
+
+		logger.Fatal("Test message")
+
+		assert.Equal(t, []int{DefaultExitCode}, codes)
+	})
+
+	t.Run("should exit when the entry is not written", func(t *testing.T) {
+		var codes []int
+
+		logger := New(
+			WithAdapter(slog.New(slog.WithWriter(&bytes.Buffer{}), slog.WithLevel(levels.Disabled))),
+			WithHooks(func(*Entry) bool { return false }),
+			WithExitFunc(func(code int) { codes = append(codes, code) }),
+		)
+
+		logger.Fatal("Test message")
+
+		assert.Equal(t, []int{DefaultExitCode}, codes)
+	})
+
+	t.Run("should not change the exit function of the adapter", func(t *testing.T) {
+		var adapterCodes, loggerCodes []int
+
+		adapter := slog.New(slog.WithWriter(&bytes.Buffer{}), slog.WithExitFunc(func(code int) {
+			adapterCodes = append(adapterCodes, code)
+		}))
+
+		logger := New(WithAdapter(adapter), WithExitFunc(func(code int) { loggerCodes = append(loggerCodes, code) }))
+
+		logger.Fatal("Test message")
+		adapter.Log(levels.Fatal, nil, nil, "Test message")
+
+		assert.Equal(t, []int{DefaultExitCode}, loggerCodes)
+		assert.Equal(t, []int{1}, adapterCodes, "the adapter used directly must keep exiting on its own")
+	})
+}
+
+func TestLoggerPanic(t *testing.T) {
+	t.Run("should panic with the message when no error is set", func(t *testing.T) {
+		var (
+			logOutput bytes.Buffer
+			calls     []string
+		)
+
+		logger := New(
+			WithAdapter(newFlushAdapter(t, &logOutput, &calls)),
+			WithShutdownHooks(func() { calls = append(calls, "hook") }),
+		)
+
+		assert.PanicsWithValue(t, "Test message", func() {
+			logger.Panic("Test %s", "message")
+		})
+
+		assert.Contains(t, logOutput.String(), "Test message")
+		assert.Equal(t, []string{"flush"}, calls)
+	})
+
+	t.Run("should panic with the error when it is set", func(t *testing.T) {
+		err := errors.New("test error")
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&bytes.Buffer{}))))
+
+		assert.PanicsWithError(t, err.Error(), func() {
+			logger.Err(err).Panic("Test message")
+		})
+	})
+
+	t.Run("should call the panic function", func(t *testing.T) {
+		var (
+			messages []string
+			errs     []error
+		)
+
+		err := errors.New("test error")
+
+		logger := New(
+			WithAdapter(slog.New(slog.WithWriter(&bytes.Buffer{}))),
+			WithPanicFunc(func(msg string, err error) {
+				messages = append(messages, msg)
+				errs = append(errs, err)
+			}),
+		)
+
+		assert.NotPanics(t, func() {
+			logger.Panic("first message")
+			logger.Err(err).Panic("second message")
+		})
+
+		assert.Equal(t, []string{"first message", "second message"}, messages)
+		assert.Equal(t, []error{nil, err}, errs)
+	})
+}
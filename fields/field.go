@@ -0,0 +1,245 @@
+package fields
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Kind is the type of the value of a Field.
+type Kind uint8
+
+const (
+	// KindAny is the kind of the values without a typed representation, that adapters encode with reflection.
+	KindAny Kind = iota
+	// KindString is the kind of the string values.
+	KindString
+	// KindInt64 is the kind of the signed integer values.
+	KindInt64
+	// KindUint64 is the kind of the unsigned integer values.
+	KindUint64
+	// KindFloat64 is the kind of the float64 values.
+	KindFloat64
+	// KindBool is the kind of the bool values.
+	KindBool
+	// KindDuration is the kind of the time.Duration values.
+	KindDuration
+	// KindTime is the kind of the time.Time values.
+	KindTime
+	// KindStringer is the kind of the fmt.Stringer values, that are written with the result of their String method.
+	KindStringer
+	// KindBytes is the kind of the byte slices, that are written as strings.
+	KindBytes
+	// KindObject is the kind of the values that are explicitly encoded with reflection, e.g. structs and maps.
+	KindObject
+)
+
+// Field is a key with a typed value. Numbers, booleans and durations are stored without boxing them on an interface,
+// so adapters can encode them with their typed methods instead of reflection.
+type Field struct {
+	Key  string
+	kind Kind
+	num  uint64
+	str  string
+	any  any
+}
+
+// String returns a field with a string value.
+func String(key, value string) Field {
+	return Field{Key: key, kind: KindString, str: value}
+}
+
+// Int returns a field with an int value.
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 returns a field with an int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, kind: KindInt64, num: uint64(value)}
+}
+
+// Uint64 returns a field with an uint64 value.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, kind: KindUint64, num: value}
+}
+
+// Float64 returns a field with a float64 value.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, kind: KindFloat64, num: math.Float64bits(value)}
+}
+
+// Bool returns a field with a bool value.
+func Bool(key string, value bool) Field {
+	var num uint64
+	if value {
+		num = 1
+	}
+
+	return Field{Key: key, kind: KindBool, num: num}
+}
+
+// Duration returns a field with a time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, kind: KindDuration, num: uint64(value)}
+}
+
+// Time returns a field with a time.Time value.
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, kind: KindTime, any: value}
+}
+
+// Stringer returns a field whose value is the result of the String method of value, that is only called when the
+// field is written. A nil value, including a typed nil pointer, is written as null.
+func Stringer(key string, value fmt.Stringer) Field {
+	if value == nil || isNilPointer(value) {
+		return Field{Key: key, kind: KindAny}
+	}
+
+	return Field{Key: key, kind: KindStringer, any: value}
+}
+
+// isNilPointer reports whether the value is a typed nil pointer, whose String method would panic when the field is
+// written if it has a value receiver.
+func isNilPointer(value any) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// Bytes returns a field with a byte slice value, that is written as a string, e.g. a request body. The slice is not
+// copied, so it must not be modified until the message is written.
+func Bytes(key string, value []byte) Field {
+	return Field{Key: key, kind: KindBytes, any: value}
+}
+
+// Object returns a field with a value encoded with reflection, e.g. a struct or a map.
+func Object(key string, value any) Field {
+	return Field{Key: key, kind: KindObject, any: value}
+}
+
+// Any returns a field with the typed representation of the value when it has one, or with KindAny otherwise. The
+// value is kept as it is, so Value returns it without boxing it again.
+func Any(key string, value any) Field {
+	f := Field{Key: key, kind: KindAny, any: value}
+
+	switch v := value.(type) {
+	case string:
+		f.kind, f.str = KindString, v
+	case bool:
+		f.kind = KindBool
+		if v {
+			f.num = 1
+		}
+	case int:
+		f.kind, f.num = KindInt64, uint64(v)
+	case int8:
+		f.kind, f.num = KindInt64, uint64(v)
+	case int16:
+		f.kind, f.num = KindInt64, uint64(v)
+	case int32:
+		f.kind, f.num = KindInt64, uint64(v)
+	case int64:
+		f.kind, f.num = KindInt64, uint64(v)
+	case uint:
+		f.kind, f.num = KindUint64, uint64(v)
+	case uint8:
+		f.kind, f.num = KindUint64, uint64(v)
+	case uint16:
+		f.kind, f.num = KindUint64, uint64(v)
+	case uint32:
+		f.kind, f.num = KindUint64, uint64(v)
+	case uint64:
+		f.kind, f.num = KindUint64, v
+	case float64:
+		f.kind, f.num = KindFloat64, math.Float64bits(v)
+	case time.Duration:
+		f.kind, f.num = KindDuration, uint64(v)
+	case time.Time:
+		f.kind = KindTime
+	}
+
+	return f
+}
+
+// Kind returns the kind of the field value.
+func (f Field) Kind() Kind {
+	return f.kind
+}
+
+// StringValue returns the value of a KindString field.
+func (f Field) StringValue() string {
+	return f.str
+}
+
+// Int64Value returns the value of a KindInt64 field.
+func (f Field) Int64Value() int64 {
+	return int64(f.num)
+}
+
+// Uint64Value returns the value of a KindUint64 field.
+func (f Field) Uint64Value() uint64 {
+	return f.num
+}
+
+// Float64Value returns the value of a KindFloat64 field.
+func (f Field) Float64Value() float64 {
+	return math.Float64frombits(f.num)
+}
+
+// BoolValue returns the value of a KindBool field.
+func (f Field) BoolValue() bool {
+	return f.num == 1
+}
+
+// DurationValue returns the value of a KindDuration field.
+func (f Field) DurationValue() time.Duration {
+	return time.Duration(f.num)
+}
+
+// TimeValue returns the value of a KindTime field.
+func (f Field) TimeValue() time.Time {
+	t, _ := f.any.(time.Time)
+	return t
+}
+
+// StringerValue returns the value of a KindStringer field.
+func (f Field) StringerValue() fmt.Stringer {
+	s, _ := f.any.(fmt.Stringer)
+	return s
+}
+
+// BytesValue returns the value of a KindBytes field.
+func (f Field) BytesValue() []byte {
+	b, _ := f.any.([]byte)
+	return b
+}
+
+// Value returns the field value as an interface. Stringer values are returned as the result of their String method
+// and byte slices as strings, as they are written.
+func (f Field) Value() any {
+	if f.any != nil && f.kind != KindStringer && f.kind != KindBytes {
+		return f.any
+	}
+
+	switch f.kind {
+	case KindString:
+		return f.str
+	case KindInt64:
+		return f.Int64Value()
+	case KindUint64:
+		return f.num
+	case KindFloat64:
+		return f.Float64Value()
+	case KindBool:
+		return f.BoolValue()
+	case KindDuration:
+		return f.DurationValue()
+	case KindStringer:
+		return f.StringerValue().String()
+	case KindBytes:
+		return string(f.BytesValue())
+	default:
+		return f.any
+	}
+}
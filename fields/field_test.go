@@ -0,0 +1,133 @@
+package fields
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFieldConstructors(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ip := net.IPv4(127, 0, 0, 1)
+	object := map[string]any{"id": 1}
+
+	tests := []struct {
+		name     string
+		field    Field
+		kind     Kind
+		expected any
+	}{
+		{name: "string", field: String("key", "value"), kind: KindString, expected: "value"},
+		{name: "int", field: Int("key", -42), kind: KindInt64, expected: int64(-42)},
+		{name: "int64", field: Int64("key", 1<<40), kind: KindInt64, expected: int64(1 << 40)},
+		{name: "uint64", field: Uint64("key", 1<<63), kind: KindUint64, expected: uint64(1 << 63)},
+		{name: "float64", field: Float64("key", 1.5), kind: KindFloat64, expected: 1.5},
+		{name: "bool", field: Bool("key", true), kind: KindBool, expected: true},
+		{name: "duration", field: Duration("key", time.Second), kind: KindDuration, expected: time.Second},
+		{name: "time", field: Time("key", ts), kind: KindTime, expected: ts},
+		{name: "stringer", field: Stringer("key", ip), kind: KindStringer, expected: "127.0.0.1"},
+		{name: "nil stringer", field: Stringer("key", nil), kind: KindAny, expected: nil},
+		{name: "typed nil stringer", field: Stringer("key", (*net.IP)(nil)), kind: KindAny, expected: nil},
+		{name: "bytes", field: Bytes("key", []byte("body")), kind: KindBytes, expected: "body"},
+		{name: "object", field: Object("key", object), kind: KindObject, expected: object},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if test.field.Key != "key" {
+				t.Errorf("Expected key 'key', but got %q", test.field.Key)
+			}
+
+			if test.field.Kind() != test.kind {
+				t.Errorf("Expected kind %v, but got %v", test.kind, test.field.Kind())
+			}
+
+			if value := test.field.Value(); !reflect.DeepEqual(value, test.expected) {
+				t.Errorf("Expected value %v (%T), but got %v (%T)", test.expected, test.expected, value, value)
+			}
+		})
+	}
+}
+
+func TestFieldTypedValues(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if v := String("key", "value").StringValue(); v != "value" {
+		t.Errorf("Expected 'value', but got %q", v)
+	}
+
+	if v := Int64("key", -1).Int64Value(); v != -1 {
+		t.Errorf("Expected -1, but got %d", v)
+	}
+
+	if v := Uint64("key", 7).Uint64Value(); v != 7 {
+		t.Errorf("Expected 7, but got %d", v)
+	}
+
+	if v := Float64("key", -2.25).Float64Value(); v != -2.25 {
+		t.Errorf("Expected -2.25, but got %v", v)
+	}
+
+	if v := Bool("key", false).BoolValue(); v {
+		t.Error("Expected false, but got true")
+	}
+
+	if v := Duration("key", time.Minute).DurationValue(); v != time.Minute {
+		t.Errorf("Expected 1m, but got %v", v)
+	}
+
+	if v := Time("key", ts).TimeValue(); !v.Equal(ts) {
+		t.Errorf("Expected %v, but got %v", ts, v)
+	}
+
+	if v := Stringer("key", time.Second).StringerValue(); v != time.Second {
+		t.Errorf("Expected the stringer, but got %v", v)
+	}
+
+	if v := Bytes("key", []byte("body")).BytesValue(); string(v) != "body" {
+		t.Errorf("Expected 'body', but got %q", v)
+	}
+}
+
+func TestAny(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value any
+		kind  Kind
+	}{
+		{name: "string", value: "value", kind: KindString},
+		{name: "bool", value: true, kind: KindBool},
+		{name: "int", value: 42, kind: KindInt64},
+		{name: "int8", value: int8(-8), kind: KindInt64},
+		{name: "int32", value: int32(32), kind: KindInt64},
+		{name: "uint", value: uint(7), kind: KindUint64},
+		{name: "uint16", value: uint16(16), kind: KindUint64},
+		{name: "float64", value: 3.25, kind: KindFloat64},
+		{name: "float32", value: float32(1.5), kind: KindAny},
+		{name: "duration", value: time.Second, kind: KindDuration},
+		{name: "time", value: ts, kind: KindTime},
+		{name: "nil", value: nil, kind: KindAny},
+		{name: "slice", value: []string{"a"}, kind: KindAny},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			field := Any("key", test.value)
+
+			if field.Kind() != test.kind {
+				t.Errorf("Expected kind %v, but got %v", test.kind, field.Kind())
+			}
+
+			if value := field.Value(); !reflect.DeepEqual(value, test.value) {
+				t.Errorf("Expected value %v (%T), but got %v (%T)", test.value, test.value, value, value)
+			}
+		})
+	}
+}
@@ -4,17 +4,24 @@ import (
 	"sync"
 )
 
+// Fields is a concurrency safe set of fields, kept in the order they were first set. Setting a key that is already
+// set replaces its value and keeps its position.
 type Fields struct {
-	mutex *sync.Mutex
-	data  map[string]any
+	mutex  *sync.Mutex
+	fields []Field
 }
 
-// New creates a new Fields instance
-func New() *Fields {
-	return &Fields{
+// New creates a new Fields instance with the provided typed fields.
+func New(fs ...Field) *Fields {
+	f := &Fields{
 		mutex: &sync.Mutex{},
-		data:  make(map[string]any),
 	}
+
+	if len(fs) > 0 {
+		f.SetFields(fs...)
+	}
+
+	return f
 }
 
 // Set sets a key-value pair in the fields
@@ -22,7 +29,7 @@ func (f *Fields) Set(key string, value any) *Fields {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	f.data[key] = value
+	f.set(Any(key, value))
 
 	return f
 }
@@ -33,7 +40,19 @@ func (f *Fields) SetMap(fields map[string]any) *Fields {
 	defer f.mutex.Unlock()
 
 	for k, v := range fields {
-		f.data[k] = v
+		f.set(Any(k, v))
+	}
+
+	return f
+}
+
+// SetFields sets the typed fields, created with constructors such as String or Int64.
+func (f *Fields) SetFields(fs ...Field) *Fields {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, field := range fs {
+		f.set(field)
 	}
 
 	return f
@@ -44,7 +63,11 @@ func (f *Fields) Get(key string) any {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	return f.data[key]
+	if i := f.index(key); i >= 0 {
+		return f.fields[i].Value()
+	}
+
+	return nil
 }
 
 // Delete removes a key from the fields
@@ -52,7 +75,9 @@ func (f *Fields) Delete(key string) *Fields {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	delete(f.data, key)
+	if i := f.index(key); i >= 0 {
+		f.fields = append(f.fields[:i], f.fields[i+1:]...)
+	}
 
 	return f
 }
@@ -62,7 +87,7 @@ func (f *Fields) Clear() *Fields {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	f.data = make(map[string]any)
+	f.fields = nil
 
 	return f
 }
@@ -72,28 +97,27 @@ func (f *Fields) Copy() *Fields {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	data := make(map[string]any)
-	for k, v := range f.data {
-		data[k] = v
-	}
-
 	return &Fields{
-		mutex: &sync.Mutex{},
-		data:  data,
+		mutex:  &sync.Mutex{},
+		fields: append([]Field(nil), f.fields...),
 	}
 }
 
 // Merge merges the fields with another fields
 func (f *Fields) Merge(fields *Fields) *Fields {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
-
-	if fields == nil {
+	if fields == nil || fields == f {
 		return f
 	}
 
-	for k, v := range fields.data {
-		f.data[k] = v
+	fields.mutex.Lock()
+	merged := append([]Field(nil), fields.fields...)
+	fields.mutex.Unlock()
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, field := range merged {
+		f.set(field)
 	}
 
 	return f
@@ -104,7 +128,7 @@ func (f *Fields) Len() int {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	return len(f.data)
+	return len(f.fields)
 }
 
 // IsEmpty returns true if the fields are empty
@@ -112,7 +136,7 @@ func (f *Fields) IsEmpty() bool {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	return len(f.data) == 0
+	return len(f.fields) == 0
 }
 
 // Data returns the fields as a map
@@ -120,10 +144,58 @@ func (f *Fields) Data() map[string]any {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	data := make(map[string]any)
-	for k, v := range f.data {
-		data[k] = v
+	data := make(map[string]any, len(f.fields))
+	for _, field := range f.fields {
+		data[field.Key] = field.Value()
 	}
 
 	return data
 }
+
+// Range calls fn for every key-value pair in the fields, in order and without copying them, until fn returns false.
+// The fields are locked while ranging, so fn must not modify them.
+func (f *Fields) Range(fn func(key string, value any) bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, field := range f.fields {
+		if !fn(field.Key, field.Value()) {
+			return
+		}
+	}
+}
+
+// RangeFields calls fn for every typed field, in order and without copying them, until fn returns false. Adapters
+// use it to encode the values with their typed methods. The fields are locked while ranging, so fn must not modify
+// them.
+func (f *Fields) RangeFields(fn func(field Field) bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, field := range f.fields {
+		if !fn(field) {
+			return
+		}
+	}
+}
+
+// set sets the field, replacing the value of the field with the same key. The fields must be locked.
+func (f *Fields) set(field Field) {
+	if i := f.index(field.Key); i >= 0 {
+		f.fields[i] = field
+		return
+	}
+
+	f.fields = append(f.fields, field)
+}
+
+// index returns the position of the field with the key, or -1 if it is not set. The fields must be locked.
+func (f *Fields) index(key string) int {
+	for i := range f.fields {
+		if f.fields[i].Key == key {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -1,6 +1,7 @@
 package fields
 
 import (
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -157,3 +158,67 @@ func TestConcurrentCopy(_ *testing.T) {
 
 	wg.Wait()
 }
+
+func TestRange(t *testing.T) {
+	f := New()
+	f.Set("key1", "value1")
+	f.Set("key2", "value2")
+
+	data := make(map[string]any)
+
+	f.Range(func(key string, value any) bool {
+		data[key] = value
+		return true
+	})
+
+	if len(data) != 2 || data["key1"] != "value1" || data["key2"] != "value2" {
+		t.Errorf("Expected all the fields, but got %v", data)
+	}
+
+	calls := 0
+
+	f.Range(func(string, any) bool {
+		calls++
+		return false
+	})
+
+	if calls != 1 {
+		t.Errorf("Expected 1 call, but got %v", calls)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	f := New(String("key1", "value1"), Int("key2", 2))
+	f.Set("key3", true)
+	f.SetFields(Int("key1", 1))
+	f.Delete("key2")
+	f.Merge(New(String("key4", "value4"), Bool("key3", false)))
+
+	var keys []string
+	var values []any
+
+	f.RangeFields(func(field Field) bool {
+		keys = append(keys, field.Key)
+		values = append(values, field.Value())
+
+		return true
+	})
+
+	if expected := []string{"key1", "key3", "key4"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected keys %v, but got %v", expected, keys)
+	}
+
+	if expected := []any{int64(1), false, "value4"}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected values %v, but got %v", expected, values)
+	}
+}
+
+func TestMergeSelf(t *testing.T) {
+	f := New(String("key1", "value1"))
+
+	f.Merge(f)
+
+	if f.Len() != 1 {
+		t.Errorf("Expected 1, but got %v", f.Len())
+	}
+}
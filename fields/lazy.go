@@ -0,0 +1,31 @@
+package fields
+
+// Lazy is a field value that is computed only when the message is written, e.g. the serialization of a request
+// body. The golog Logger calls it once, after checking the level of the message, so it is never called for messages
+// that are filtered out:
+//
+//	logger.Field("body", fields.Lazy(func() any { return string(dump(req)) })).Debug("request received")
+type Lazy func() any
+
+// Resolve replaces the Lazy values of the fields with their results.
+func (f *Fields) Resolve() *Fields {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i, field := range f.fields {
+		if lazy, ok := field.any.(Lazy); ok && field.kind == KindAny {
+			f.fields[i] = Any(field.Key, lazy.value())
+		}
+	}
+
+	return f
+}
+
+// value returns the result of the lazy function, or nil if the function is nil.
+func (l Lazy) value() any {
+	if l == nil {
+		return nil
+	}
+
+	return l()
+}
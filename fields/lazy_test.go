@@ -0,0 +1,39 @@
+package fields
+
+import (
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	calls := 0
+
+	f := New().
+		Set("lazy", Lazy(func() any {
+			calls++
+			return "computed"
+		})).
+		Set("nil_lazy", Lazy(nil)).
+		Set("value", 1)
+
+	if calls != 0 {
+		t.Fatalf("Expected the lazy value not to be computed before resolving, but it was called %d times", calls)
+	}
+
+	f.Resolve().Resolve()
+
+	if calls != 1 {
+		t.Errorf("Expected the lazy value to be computed once, but it was called %d times", calls)
+	}
+
+	if value := f.Get("lazy"); value != "computed" {
+		t.Errorf("Expected 'computed', but got %v", value)
+	}
+
+	if value := f.Get("nil_lazy"); value != nil {
+		t.Errorf("Expected nil, but got %v", value)
+	}
+
+	if value := f.Get("value"); value != 1 {
+		t.Errorf("Expected 1, but got %v", value)
+	}
+}
@@ -4,8 +4,7 @@
 //
 // Also, it adds some features that are not present in zerolog, like context fields. This context fields
 // are fields that are added to every log message, and are stored globally. To store them, whe should add
-// a value to the context that identifies the execution, e.g. a request id using the key stored on
-// glog/context.ExecutionContextKey.
+// a value to the context that identifies the execution, e.g. a request id using golog.NewExecution.
 //
 // This context isolation is useful when we want to add fields to every log message of a specific execution,
 // and we have multiple executions running at the same time, e.g. multiple requests.
@@ -17,12 +16,11 @@
 //	 	import (
 //	 		"context"
 //	 		"github.com/danteay/golog"
-//			logcontext "github.com/danteay/golog/context"
 //	 	)
 //
 //		func main() {
-//			ctx := context.WithValue(context.Background(), logcontext.ExecutionContextKey, "some-exec-id")
-//			logger := New(ctx, WithWriter(&logOutput), WithLevel(DebugLevel), Colored())
+//			ctx := golog.NewExecution(context.Background(), "some-exec-id")
+//			logger := golog.New().SetContext(ctx)
 //
 //			logger.SetContextFields(map[string]any{
 //				"stage": "dev",
@@ -30,30 +28,52 @@
 //			})
 //
 //			logger.Info("Hello %s", "world")
-//			// {"level":"info","message":"Hello world","stage":"dev","app-name":"some-name","time":"2020-01-01T00:00:00Z"}
+//			// {"level":"info","message":"Hello world","execution_id":"some-exec-id","stage":"dev","app-name":"some-name","time":"2020-01-01T00:00:00Z"}
 //
 //			logger.Warn("This is a warning")
-//			// {"level":"warn","message":"This is a warning","stage":"dev","app-name":"some-name","time":"2020-01-01T00:00:00Z"}
+//			// {"level":"warn","message":"This is a warning","execution_id":"some-exec-id","stage":"dev","app-name":"some-name","time":"2020-01-01T00:00:00Z"}
 //
 //			logger.Error("This is an error")
-//			// {"level":"error","message":"This is an error","stage":"dev","app-name":"some-name","time":"2020-01-01T00:00:00Z"}
+//			// {"level":"error","message":"This is an error","execution_id":"some-exec-id","stage":"dev","app-name":"some-name","time":"2020-01-01T00:00:00Z"}
 //
 //			logger.FlushContextFields()
 //
 //			logger.Info("Hello %s", "world")
-//			// {"level":"info","message":"Hello world","time":"2020-01-01T00:00:00Z"}
+//			// {"level":"info","message":"Hello world","execution_id":"some-exec-id","time":"2020-01-01T00:00:00Z"}
 //
 //			logger.Warn("This is a warning")
-//			// {"level":"warn","message":"This is a warning","time":"2020-01-01T00:00:00Z"}
+//			// {"level":"warn","message":"This is a warning","execution_id":"some-exec-id","time":"2020-01-01T00:00:00Z"}
 //
 //			logger.Error("This is an error")
-//			// {"level":"error","message":"This is an error","time":"2020-01-01T00:00:00Z"}
+//			// {"level":"error","message":"This is an error","execution_id":"some-exec-id","time":"2020-01-01T00:00:00Z"}
 //		}
 package golog
 
-import "github.com/danteay/golog/internal/contextfields"
+import (
+	"context"
+	"time"
+
+	"github.com/danteay/golog/internal/contextfields"
+)
 
 // FlushAllContextFields removes all stored context fields.
 func FlushAllContextFields() {
 	contextfields.FlushAll()
 }
+
+// ContextWithFields returns a copy of ctx that carries the provided context fields. Unlike SetContextFields, the
+// fields are not kept in the global store, they live as long as the context does and never need to be flushed.
+func ContextWithFields(ctx context.Context, fields map[string]any) context.Context {
+	return contextfields.WithFields(ctx, fields)
+}
+
+// SetContextFieldsTTL sets the maximum time the context fields of an execution are kept in the global store without
+// being used. A zero or negative ttl disables the eviction, which is the default.
+func SetContextFieldsTTL(ttl time.Duration) {
+	contextfields.SetTTL(ttl)
+}
+
+// TrackedExecutions returns the number of executions that currently have context fields in the global store.
+func TrackedExecutions() int {
+	return contextfields.Executions()
+}
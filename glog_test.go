@@ -0,0 +1,72 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/internal/contextfields"
+	"github.com/danteay/golog/levels"
+)
+
+func TestContextWithFields(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	before := TrackedExecutions()
+
+	adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+	ctx := ContextWithFields(context.Background(), map[string]any{"ctx_key": "some context val"})
+
+	New(WithAdapter(adapter)).SetContext(ctx).Info("Test message")
+
+	res := testMsg{}
+
+	if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+		t.Fatal(errUnmarshal)
+	}
+
+	assert.Equal(t, "some context val", res.CtxKey)
+	assert.Equal(t, before, TrackedExecutions())
+}
+
+func TestFlushContextFieldsOnDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextfields.ExecutionContextKey, "some-exec-id"))
+
+	logger := New().SetContext(ctx)
+	defer logger.FlushContextFields()
+
+	before := TrackedExecutions()
+
+	logger.SetContextFields(map[string]any{"ctx_key": "some context val"}).FlushContextFieldsOnDone()
+	assert.Equal(t, before+1, TrackedExecutions())
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		return TrackedExecutions() == before
+	}, time.Second, time.Millisecond)
+}
+
+func TestSetContextFieldsTTL(t *testing.T) {
+	SetContextFieldsTTL(10 * time.Millisecond)
+	defer SetContextFieldsTTL(0)
+
+	ctx := context.WithValue(context.Background(), contextfields.ExecutionContextKey, "some-exec-id")
+
+	logger := New().SetContext(ctx)
+	defer logger.FlushContextFields()
+
+	before := TrackedExecutions()
+
+	logger.SetContextFields(map[string]any{"ctx_key": "some context val"})
+	assert.Equal(t, before+1, TrackedExecutions())
+
+	assert.Eventually(t, func() bool {
+		return TrackedExecutions() == before
+	}, time.Second, 5*time.Millisecond)
+}
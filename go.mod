@@ -6,6 +6,7 @@ require (
 	github.com/danteay/golog/adapters/slog v0.4.0
 	github.com/danteay/golog/fields v0.1.0
 	github.com/danteay/golog/levels v0.1.1
+	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.9.0
 )
 
@@ -4,25 +4,43 @@ import (
 	"context"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/danteay/golog/fields"
 )
 
 const (
-	// ExecutionContextKey is the key used to find in context the execution id to store global fields.
+	// ExecutionContextKey is the legacy untyped key used to find in context the execution id to store global fields.
+	//
+	// Deprecated: use WithExecution to store the execution id on context.
 	ExecutionContextKey = "logger:execution_context"
 
 	// DefaultContextValue is the default value used for the execution id.
 	DefaultContextValue = "default"
 )
 
+type fieldsContextKey struct{}
+
+type executionContextKey struct{}
+
+// executionID is the store key of the executions identified with WithExecution, so their ids never collide with the
+// values of the legacy ExecutionContextKey or with DefaultContextValue.
+type executionID string
+
+type execution struct {
+	fields     *fields.Fields
+	lastAccess time.Time
+}
+
 var (
-	contextFields map[any]*fields.Fields
+	contextFields map[any]*execution
 	mutex         *sync.Mutex
+	ttl           time.Duration
+	lastSweep     time.Time
 )
 
 func init() {
-	contextFields = make(map[any]*fields.Fields)
+	contextFields = make(map[any]*execution)
 	mutex = &sync.Mutex{}
 }
 
@@ -33,15 +51,107 @@ func SetFields(ctx context.Context, newFields map[string]any) {
 	mutex.Lock()
 	defer mutex.Unlock()
 
+	now := time.Now()
+	evictExpired(now)
+
 	val, exists := contextFields[ctxVal]
 
 	if !exists || val == nil {
-		contextFields[ctxVal] = fields.New()
+		val = &execution{fields: fields.New()}
+		contextFields[ctxVal] = val
+	}
+
+	val.lastAccess = now
+	val.fields.SetMap(newFields)
+}
+
+// WithExecution returns a copy of ctx identified by the provided execution id.
+func WithExecution(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, executionContextKey{}, id)
+}
+
+// ExecutionID returns the execution id stored on ctx with WithExecution.
+func ExecutionID(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+
+	id, ok := ctx.Value(executionContextKey{}).(string)
+
+	return id, ok
+}
+
+// ExecutionKey returns the key that identifies the execution stored on ctx, either with WithExecution or with the
+// legacy ExecutionContextKey. It returns false when ctx does not belong to any execution.
+func ExecutionKey(ctx context.Context) (any, bool) {
+	key := getExecKey(ctx)
+	if key == nil || reflect.DeepEqual(key, DefaultContextValue) {
+		return nil, false
+	}
+
+	return key, true
+}
+
+// WithFields returns a copy of ctx that carries the provided fields merged with any fields already stored on it.
+// Fields stored on the context are released with the context itself, so they don't need to be flushed.
+func WithFields(ctx context.Context, newFields map[string]any) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	for key, value := range newFields {
-		contextFields[ctxVal].Set(key, value)
+	f := fields.New()
+
+	if parent, ok := ctx.Value(fieldsContextKey{}).(*fields.Fields); ok {
+		f.Merge(parent)
 	}
+
+	f.SetMap(newFields)
+
+	return context.WithValue(ctx, fieldsContextKey{}, f)
+}
+
+// FlushOnDone removes the global fields of the execution stored on context once the context is done.
+func FlushOnDone(ctx context.Context) {
+	if ctx == nil || ctx.Done() == nil {
+		return
+	}
+
+	context.AfterFunc(ctx, func() {
+		Flush(ctx)
+	})
+}
+
+// SetTTL sets the maximum time the global fields of an execution are kept without being accessed. Expired
+// executions are evicted lazily while the store is accessed. A zero or negative ttl disables the eviction, which is
+// the default. The fields of the default execution are never evicted.
+func SetTTL(newTTL time.Duration) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	ttl = newTTL
+}
+
+// TTL returns the maximum time the global fields of an execution are kept without being accessed, as set with
+// SetTTL.
+func TTL() time.Duration {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return ttl
+}
+
+// Executions returns the number of executions that currently have global fields stored.
+func Executions() int {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	sweep(time.Now())
+
+	return len(contextFields)
 }
 
 // Flush removes the global fields according the stored execution id on context.
@@ -59,11 +169,12 @@ func FlushAll() {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	contextFields = make(map[any]*fields.Fields)
+	contextFields = make(map[any]*execution)
 }
 
-// Fields returns the global fields according the stored execution id on context.
-func Fields(ctx context.Context) *fields.Fields {
+// Fields returns the global fields according the stored execution id on context, merged with the fields stored on
+// the context itself. The id of executions identified with WithExecution is set as the idField field.
+func Fields(ctx context.Context, idField string) *fields.Fields {
 	ctxVal := getExecKey(ctx)
 
 	execs := []any{ctxVal}
@@ -72,25 +183,74 @@ func Fields(ctx context.Context) *fields.Fields {
 		execs = append(execs, DefaultContextValue)
 	}
 
+	f := fields.New()
+
+	if id, ok := ExecutionID(ctx); ok {
+		f.Set(idField, id)
+	}
+
 	mutex.Lock()
-	defer mutex.Unlock()
 
-	f := fields.New()
+	now := time.Now()
+	evictExpired(now)
 
 	for _, exec := range execs {
 		if val, exists := contextFields[exec]; exists && val != nil {
-			f.Merge(val)
+			val.lastAccess = now
+			f.Merge(val.fields)
+		}
+	}
+
+	mutex.Unlock()
+
+	if ctx != nil {
+		if ctxFields, ok := ctx.Value(fieldsContextKey{}).(*fields.Fields); ok {
+			f.Merge(ctxFields)
 		}
 	}
 
 	return f
 }
 
+// evictExpired removes the executions that were not accessed within the configured ttl. It must be called while
+// holding the mutex.
+func evictExpired(now time.Time) {
+	if now.Sub(lastSweep) < ttl {
+		return
+	}
+
+	sweep(now)
+}
+
+// sweep removes all the expired executions regardless of the last time the store was swept. It must be called while
+// holding the mutex.
+func sweep(now time.Time) {
+	if ttl <= 0 {
+		return
+	}
+
+	lastSweep = now
+
+	for key, val := range contextFields {
+		if reflect.DeepEqual(key, DefaultContextValue) {
+			continue
+		}
+
+		if now.Sub(val.lastAccess) >= ttl {
+			delete(contextFields, key)
+		}
+	}
+}
+
 func getExecKey(ctx context.Context) any {
 	if ctx == nil {
 		return nil
 	}
 
+	if id, ok := ExecutionID(ctx); ok {
+		return executionID(id)
+	}
+
 	val := ctx.Value(ExecutionContextKey)
 
 	if val == nil {
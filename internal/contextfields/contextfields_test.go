@@ -4,8 +4,11 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 )
 
+const testIDField = "execution_id"
+
 func TestSetFields(t *testing.T) {
 	t.Run("should set fields from execution context", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), ExecutionContextKey, "test_key")
@@ -15,7 +18,7 @@ func TestSetFields(t *testing.T) {
 
 		SetFields(ctx, newFields)
 
-		f := Fields(ctx)
+		f := Fields(ctx, testIDField)
 		if f.Len() != 2 {
 			t.Errorf("Expected 2 contextFields, but got %d", f.Len())
 		}
@@ -33,7 +36,7 @@ func TestSetFields(t *testing.T) {
 
 		SetFields(ctx, newFields)
 
-		f := Fields(ctx)
+		f := Fields(ctx, testIDField)
 		if f.Len() != 2 {
 			t.Errorf("Expected 2 contextFields, but got %d", f.Len())
 		}
@@ -55,7 +58,7 @@ func TestSetFields(t *testing.T) {
 		SetFields(ctx2, map[string]any{"key2": "value2"})
 		SetFields(ctx3, map[string]any{"key3": "value3"})
 
-		f1 := Fields(ctx1)
+		f1 := Fields(ctx1, testIDField)
 		if f1.Len() != 2 {
 			t.Errorf("Expected 2 contextFields, but got %d", f1.Len())
 		}
@@ -64,7 +67,7 @@ func TestSetFields(t *testing.T) {
 			t.Errorf("Expected value1, but got %v", val)
 		}
 
-		f2 := Fields(ctx2)
+		f2 := Fields(ctx2, testIDField)
 		if f2.Len() != 2 {
 			t.Errorf("Expected 2 contextFields, but got %d", f2.Len())
 		}
@@ -73,7 +76,7 @@ func TestSetFields(t *testing.T) {
 			t.Errorf("Expected value2, but got %v", val)
 		}
 
-		f3 := Fields(ctx3)
+		f3 := Fields(ctx3, testIDField)
 		if f3.Len() != 1 {
 			t.Errorf("Expected 1 contextFields, but got %d", f3.Len())
 		}
@@ -92,7 +95,7 @@ func TestFlush(t *testing.T) {
 
 	Flush(ctx)
 
-	f := Fields(ctx)
+	f := Fields(ctx, testIDField)
 	if f.Len() > 0 {
 		t.Error("Fields not flushed correctly")
 	}
@@ -105,7 +108,7 @@ func TestFields(t *testing.T) {
 	newFields := map[string]any{"key1": "value1", "key2": "value2"}
 	SetFields(ctx, newFields)
 
-	f := Fields(ctx)
+	f := Fields(ctx, testIDField)
 
 	if f.Len() != 2 {
 		t.Errorf("Expected 2 contextFields, but got %d", f.Len())
@@ -135,7 +138,7 @@ func TestConcurrentSetFields(t *testing.T) {
 
 	wg.Wait()
 
-	f := Fields(ctx)
+	f := Fields(ctx, testIDField)
 	t.Log(f)
 	if f.Len() != 1 {
 		t.Errorf("Expected 1 field, but got %d", f.Len())
@@ -162,8 +165,194 @@ func TestConcurrentFlush(t *testing.T) {
 
 	wg.Wait()
 
-	f := Fields(ctx)
+	f := Fields(ctx, testIDField)
 	if f.Len() > 0 {
 		t.Error("Fields not flushed correctly in concurrent Flush")
 	}
 }
+
+func TestWithFields(t *testing.T) {
+	t.Run("should store fields on context", func(t *testing.T) {
+		before := Executions()
+
+		ctx := WithFields(context.Background(), map[string]any{"key1": "value1"})
+
+		f := Fields(ctx, testIDField)
+		if f.Get("key1") != "value1" {
+			t.Errorf("Expected value1, but got %v", f.Get("key1"))
+		}
+
+		if Executions() != before {
+			t.Errorf("Expected %d executions, but got %d", before, Executions())
+		}
+	})
+
+	t.Run("should not modify parent context fields", func(t *testing.T) {
+		parent := WithFields(context.Background(), map[string]any{"key1": "value1"})
+		child := WithFields(parent, map[string]any{"key1": "override", "key2": "value2"})
+
+		pf := Fields(parent, testIDField)
+		if pf.Len() != 1 || pf.Get("key1") != "value1" {
+			t.Errorf("Parent fields modified: %v", pf.Data())
+		}
+
+		cf := Fields(child, testIDField)
+		if cf.Len() != 2 || cf.Get("key1") != "override" || cf.Get("key2") != "value2" {
+			t.Errorf("Child fields not set correctly: %v", cf.Data())
+		}
+	})
+
+	t.Run("should take precedence over global fields", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ExecutionContextKey, "test_key")
+		defer Flush(ctx)
+
+		SetFields(ctx, map[string]any{"key1": "global", "key2": "value2"})
+		ctx = WithFields(ctx, map[string]any{"key1": "local"})
+
+		f := Fields(ctx, testIDField)
+		if f.Get("key1") != "local" || f.Get("key2") != "value2" {
+			t.Errorf("Fields not merged correctly: %v", f.Data())
+		}
+	})
+}
+
+func TestFlushOnDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ExecutionContextKey, "test_key"))
+	defer Flush(ctx)
+
+	SetFields(ctx, map[string]any{"key1": "value1"})
+	FlushOnDone(ctx)
+
+	if Fields(ctx, testIDField).Len() != 1 {
+		t.Fatal("Fields not set correctly")
+	}
+
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for Fields(ctx, testIDField).Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if Fields(ctx, testIDField).Len() > 0 {
+		t.Error("Fields not flushed after context was done")
+	}
+}
+
+func TestSetTTL(t *testing.T) {
+	SetTTL(10 * time.Millisecond)
+	defer SetTTL(0)
+
+	defaultCtx := context.Background()
+	ctx := context.WithValue(context.Background(), ExecutionContextKey, "test_key")
+	defer Flush(defaultCtx)
+	defer Flush(ctx)
+
+	SetFields(defaultCtx, map[string]any{"key1": "value1"})
+	SetFields(ctx, map[string]any{"key2": "value2"})
+
+	before := Executions()
+
+	time.Sleep(20 * time.Millisecond)
+
+	if Executions() != before-1 {
+		t.Errorf("Expected %d executions, but got %d", before-1, Executions())
+	}
+
+	f := Fields(ctx, testIDField)
+	if f.Len() != 1 || f.Get("key1") != "value1" {
+		t.Errorf("Expected only default fields to be kept, but got %v", f.Data())
+	}
+}
+
+func TestExecutions(t *testing.T) {
+	ctx1 := context.WithValue(context.Background(), ExecutionContextKey, "test_key1")
+	ctx2 := context.WithValue(context.Background(), ExecutionContextKey, "test_key2")
+	defer Flush(ctx1)
+	defer Flush(ctx2)
+
+	before := Executions()
+
+	SetFields(ctx1, map[string]any{"key1": "value1"})
+	SetFields(ctx2, map[string]any{"key2": "value2"})
+
+	if Executions() != before+2 {
+		t.Errorf("Expected %d executions, but got %d", before+2, Executions())
+	}
+
+	Flush(ctx1)
+
+	if Executions() != before+1 {
+		t.Errorf("Expected %d executions, but got %d", before+1, Executions())
+	}
+}
+
+func TestWithExecution(t *testing.T) {
+	t.Run("should group fields by execution id", func(t *testing.T) {
+		ctx1 := WithExecution(context.Background(), "exec1")
+		ctx2 := WithExecution(context.Background(), "exec2")
+		defer Flush(ctx1)
+		defer Flush(ctx2)
+
+		SetFields(ctx1, map[string]any{"key1": "value1"})
+		SetFields(ctx2, map[string]any{"key2": "value2"})
+
+		f1 := Fields(ctx1, testIDField)
+		if f1.Len() != 2 || f1.Get("key1") != "value1" || f1.Get(testIDField) != "exec1" {
+			t.Errorf("Fields not set correctly: %v", f1.Data())
+		}
+
+		f2 := Fields(ctx2, testIDField)
+		if f2.Len() != 2 || f2.Get("key2") != "value2" || f2.Get(testIDField) != "exec2" {
+			t.Errorf("Fields not set correctly: %v", f2.Data())
+		}
+	})
+
+	t.Run("should return execution id", func(t *testing.T) {
+		id, ok := ExecutionID(WithExecution(context.Background(), "exec1"))
+		if !ok || id != "exec1" {
+			t.Errorf("Expected exec1, but got %v", id)
+		}
+
+		if _, ok := ExecutionID(context.Background()); ok {
+			t.Error("Expected no execution id on background context")
+		}
+	})
+
+	t.Run("should not share the default fields with an execution named default", func(t *testing.T) {
+		ctx := WithExecution(context.Background(), DefaultContextValue)
+		defer Flush(ctx)
+
+		SetFields(ctx, map[string]any{"key1": "value1"})
+
+		if f := Fields(context.Background(), testIDField); f.Get("key1") != nil {
+			t.Errorf("Expected no default fields, but got %v", f.Data())
+		}
+
+		if _, ok := ExecutionKey(ctx); !ok {
+			t.Error("Expected an execution key for the execution named default")
+		}
+	})
+
+	t.Run("should not emit execution id for legacy key", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), ExecutionContextKey, "exec1")
+
+		if f := Fields(ctx, testIDField); f.Get(testIDField) != nil {
+			t.Errorf("Expected no execution id field, but got %v", f.Get(testIDField))
+		}
+	})
+}
+
+func TestExecutionKey(t *testing.T) {
+	if key, ok := ExecutionKey(WithExecution(context.Background(), "exec1")); !ok || key != executionID("exec1") {
+		t.Errorf("Expected exec1, but got %v", key)
+	}
+
+	if key, ok := ExecutionKey(context.WithValue(context.Background(), ExecutionContextKey, "exec2")); !ok || key != "exec2" {
+		t.Errorf("Expected exec2, but got %v", key)
+	}
+
+	if _, ok := ExecutionKey(context.Background()); ok {
+		t.Error("Expected no execution key on background context")
+	}
+}
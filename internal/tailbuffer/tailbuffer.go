@@ -0,0 +1,154 @@
+package tailbuffer
+
+import (
+	"sync"
+	"time"
+)
+
+// Store keeps a bounded buffer of items for every execution.
+type Store[T any] struct {
+	mutex     *sync.Mutex
+	size      int
+	maxKeys   int
+	ttl       func() time.Duration
+	lastSweep time.Time
+	buffers   map[any]*buffer[T]
+}
+
+type buffer[T any] struct {
+	items      []T
+	lastAccess time.Time
+}
+
+// New creates a new store that keeps up to size items per execution, for up to maxKeys executions. When a buffer is
+// full, the oldest item is discarded to make room for the new one, and when the store is full, the buffer that was
+// accessed least recently is discarded to make room for a new execution. Buffers that are not accessed within the
+// duration returned by ttl are evicted lazily while the store is accessed. A nil ttl, or one returning a zero or
+// negative duration, disables the eviction by ttl, and a zero or negative maxKeys does not limit the executions.
+func New[T any](size, maxKeys int, ttl func() time.Duration) *Store[T] {
+	return &Store[T]{
+		mutex:   &sync.Mutex{},
+		size:    size,
+		maxKeys: maxKeys,
+		ttl:     ttl,
+		buffers: make(map[any]*buffer[T]),
+	}
+}
+
+// Add appends an item to the buffer of the execution. It returns true when the buffer of the execution was created
+// by this call.
+func (s *Store[T]) Add(key any, item T) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.evictExpired(now)
+
+	buf, exists := s.buffers[key]
+	if !exists {
+		s.makeRoom(now)
+
+		buf = &buffer[T]{}
+		s.buffers[key] = buf
+	}
+
+	if s.size > 0 && len(buf.items) >= s.size {
+		buf.items = append(buf.items[:0], buf.items[len(buf.items)-s.size+1:]...)
+	}
+
+	buf.items = append(buf.items, item)
+	buf.lastAccess = now
+
+	return !exists
+}
+
+// Drain removes and returns the buffered items of the execution, in the order they were added.
+func (s *Store[T]) Drain(key any) []T {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	buf, exists := s.buffers[key]
+	if !exists {
+		return nil
+	}
+
+	delete(s.buffers, key)
+
+	return buf.items
+}
+
+// Discard removes the buffered items of the execution.
+func (s *Store[T]) Discard(key any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.buffers, key)
+}
+
+// Executions returns the number of executions with buffered items.
+func (s *Store[T]) Executions() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep(time.Now())
+
+	return len(s.buffers)
+}
+
+// makeRoom discards the buffer that was accessed least recently when the store is full, after removing the expired
+// ones. It must be called while holding the mutex.
+func (s *Store[T]) makeRoom(now time.Time) {
+	if s.maxKeys <= 0 || len(s.buffers) < s.maxKeys {
+		return
+	}
+
+	s.sweep(now)
+
+	if len(s.buffers) < s.maxKeys {
+		return
+	}
+
+	var (
+		oldestKey    any
+		oldestAccess time.Time
+	)
+
+	for key, buf := range s.buffers {
+		if oldestKey == nil || buf.lastAccess.Before(oldestAccess) {
+			oldestKey, oldestAccess = key, buf.lastAccess
+		}
+	}
+
+	delete(s.buffers, oldestKey)
+}
+
+// evictExpired removes the buffers that were not accessed within the ttl, at most once per ttl. It must be called
+// while holding the mutex.
+func (s *Store[T]) evictExpired(now time.Time) {
+	if s.ttl == nil || now.Sub(s.lastSweep) < s.ttl() {
+		return
+	}
+
+	s.sweep(now)
+}
+
+// sweep removes all the expired buffers regardless of the last time the store was swept. It must be called while
+// holding the mutex.
+func (s *Store[T]) sweep(now time.Time) {
+	if s.ttl == nil {
+		return
+	}
+
+	ttl := s.ttl()
+	if ttl <= 0 {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, buf := range s.buffers {
+		if now.Sub(buf.lastAccess) >= ttl {
+			delete(s.buffers, key)
+		}
+	}
+}
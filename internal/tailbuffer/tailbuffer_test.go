@@ -0,0 +1,134 @@
+package tailbuffer
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAdd(t *testing.T) {
+	s := New[int](0, 0, nil)
+
+	if !s.Add("exec1", 1) {
+		t.Error("Expected first add to create the buffer")
+	}
+
+	if s.Add("exec1", 2) {
+		t.Error("Expected second add to reuse the buffer")
+	}
+
+	if got := s.Drain("exec1"); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Expected [1 2], but got %v", got)
+	}
+}
+
+func TestAddBounded(t *testing.T) {
+	s := New[int](2, 0, nil)
+
+	for i := 1; i <= 4; i++ {
+		s.Add("exec1", i)
+	}
+
+	if got := s.Drain("exec1"); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Errorf("Expected [3 4], but got %v", got)
+	}
+}
+
+func TestMaxKeys(t *testing.T) {
+	s := New[int](0, 2, nil)
+
+	s.Add("exec1", 1)
+	time.Sleep(time.Millisecond)
+	s.Add("exec2", 2)
+	time.Sleep(time.Millisecond)
+	s.Add("exec1", 3)
+	time.Sleep(time.Millisecond)
+	s.Add("exec3", 4)
+
+	if s.Executions() != 2 {
+		t.Errorf("Expected 2 executions, but got %d", s.Executions())
+	}
+
+	if got := s.Drain("exec2"); got != nil {
+		t.Errorf("Expected the least recently used buffer to be evicted, but got %v", got)
+	}
+
+	if got := s.Drain("exec1"); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Expected [1 3], but got %v", got)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	s := New[int](0, 0, nil)
+	s.Add("exec1", 1)
+	s.Add("exec2", 2)
+
+	if got := s.Drain("exec1"); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Expected [1], but got %v", got)
+	}
+
+	if got := s.Drain("exec1"); got != nil {
+		t.Errorf("Expected nil, but got %v", got)
+	}
+
+	if s.Executions() != 1 {
+		t.Errorf("Expected 1 execution, but got %d", s.Executions())
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	s := New[int](0, 0, nil)
+	s.Add("exec1", 1)
+	s.Discard("exec1")
+
+	if s.Executions() != 0 {
+		t.Errorf("Expected 0 executions, but got %d", s.Executions())
+	}
+}
+
+func TestTTL(t *testing.T) {
+	ttl := 10 * time.Millisecond
+	s := New[int](0, 0, func() time.Duration { return ttl })
+
+	s.Add("exec1", 1)
+	time.Sleep(20 * time.Millisecond)
+	s.Add("exec2", 2)
+
+	if s.Executions() != 1 {
+		t.Errorf("Expected 1 execution, but got %d", s.Executions())
+	}
+
+	if got := s.Drain("exec1"); got != nil {
+		t.Errorf("Expected the expired buffer to be evicted, but got %v", got)
+	}
+
+	ttl = 0
+	s.Add("exec1", 1)
+	time.Sleep(20 * time.Millisecond)
+
+	if s.Executions() != 2 {
+		t.Errorf("Expected 2 executions with the eviction disabled, but got %d", s.Executions())
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	s := New[int](0, 0, nil)
+
+	const goroutines = 100
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			s.Add("exec1", i)
+			wg.Done()
+		}(i)
+	}
+
+	wg.Wait()
+
+	if got := s.Drain("exec1"); len(got) != goroutines {
+		t.Errorf("Expected %d items, but got %d", goroutines, len(got))
+	}
+}
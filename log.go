@@ -1,60 +1,251 @@
 package golog
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/danteay/golog/fields"
 	"github.com/danteay/golog/internal/contextfields"
-	"github.com/danteay/golog/internal/errors"
 	"github.com/danteay/golog/levels"
 )
 
 // Log logs a message with the provided level, message and arguments.
 func (l *Logger) Log(level levels.Level, msg string, args ...any) {
-	defer l.reset()
+	l.logf(level, msg, args...)
+}
 
-	if level <= levels.Disabled {
+// logf formats the message and logs it, capturing the caller when it is enabled. Messages with a level disabled on
+// the adapter are discarded before formatting them or collecting their fields. It must be called directly from the
+// exported log methods, so the caller is always found callerDepth frames above.
+func (l *Logger) logf(level levels.Level, msg string, args ...any) {
+	if !l.enabled(level) || !l.sample(level, msg) {
 		return
 	}
 
-	l.fields.Merge(contextfields.Fields(l.ctx))
+	var pc uintptr
+	if l.caller {
+		pc = callerPC(callerDepth + l.callerSkip)
+	}
+
+	l.log(&Entry{Time: time.Now(), Level: level, Message: fmt.Sprintf(msg, args...), Format: msg, Args: args}, nil, pc)
+}
 
+// log completes the entry of an already formatted message with the logger error and fields, adding the extra fields
+// over the logger and context fields, and writes it unless it is kept on the tail buffer. The source field is added
+// when pc is not zero, and the fields, chain and stack of the error when the logger has one. Fatal and Panic entries
+// exit or panic once they are written.
+func (l *Logger) log(entry *Entry, extra *fields.Fields, pc uintptr) {
+	logFields := l.fields.Copy()
 	if l.err != nil {
-		l.fields.Set("stack", errors.GetStackTrace())
+		logFields = ErrorFields(l.err).Merge(l.fields)
 	}
 
-	l.logger.Log(level, l.err, l.fields, msg, args...)
+	entry.Err = l.err
+	entry.Fields = logFields.Merge(contextfields.Fields(l.ctx, ExecutionIDField)).Merge(extra)
+
+	if pc != 0 {
+		entry.Fields.Set(SourceField, newSource(pc))
+	}
+
+	if l.err != nil {
+		stack, ok := ErrorStack(l.err)
+		if !ok {
+			stack = callStack()
+		}
+
+		entry.Fields.Set(ErrorChainField, NewErrorInfo(l.err)).Set(StackField, stack)
+	}
+
+	if l.bufferTail(entry) {
+		return
+	}
+
+	l.write(entry)
+	l.terminate(entry)
 }
 
 // Debug logs a message with the Debug level.
 func (l *Logger) Debug(msg string, args ...any) {
-	l.Log(levels.Debug, msg, args...)
+	l.logf(levels.Debug, msg, args...)
 }
 
 // Info logs a message with the Info level.
 func (l *Logger) Info(msg string, args ...any) {
-	l.Log(levels.Info, msg, args...)
+	l.logf(levels.Info, msg, args...)
 }
 
 // Warn logs a message with the Warn level.
 func (l *Logger) Warn(msg string, args ...any) {
-	l.Log(levels.Warn, msg, args...)
+	l.logf(levels.Warn, msg, args...)
 }
 
 // Error logs a message with the Error level.
 func (l *Logger) Error(msg string, args ...any) {
-	l.Log(levels.Error, msg, args...)
+	l.logf(levels.Error, msg, args...)
 }
 
-// Fatal logs a message with the Fatal level.
+// Fatal logs a message with the Fatal level. After writing it, the shutdown hooks run, the adapter is flushed and
+// the program exits with the configured exit function and code.
 func (l *Logger) Fatal(msg string, args ...any) {
-	l.Log(levels.Fatal, msg, args...)
+	l.logf(levels.Fatal, msg, args...)
 }
 
-// Panic logs a message with the Panic level.
+// Panic logs a message with the Panic level. After writing it, the adapter is flushed and the configured panic
+// function is called, that by default panics with the logger error or with the message when no error is set.
 func (l *Logger) Panic(msg string, args ...any) {
-	l.Log(levels.Panic, msg, args...)
+	l.logf(levels.Panic, msg, args...)
 }
 
-func (l *Logger) reset() {
-	l.fields = fields.New()
-	l.err = nil
+// write resolves the lazy fields of the entry, runs the registered hooks over it and sends it to the adapter if none
+// of them dropped it. The entry is written with LogEntry or Emit when the adapter implements them, otherwise the panic
+// raised by the adapter after writing a Panic entry is recovered, as the Logger panics on its own afterwards.
+func (l *Logger) write(entry *Entry) {
+	entry.Fields.Resolve()
+
+	for _, hook := range l.hooks {
+		if !hook(entry) {
+			return
+		}
+	}
+
+	if entryAdapter, ok := l.logger.(EntryAdapter); ok {
+		entryAdapter.LogEntry(entry)
+		return
+	}
+
+	if emitter, ok := l.logger.(Emitter); ok {
+		emitter.Emit(entry.Time, entry.Level, entry.Err, entry.Fields, "%s", entry.Message)
+		return
+	}
+
+	if entry.Level == levels.Panic {
+		defer func() {
+			_ = recover()
+		}()
+	}
+
+	if timed, ok := l.logger.(TimedAdapter); ok {
+		timed.LogAt(entry.Time, entry.Level, entry.Err, entry.Fields, "%s", entry.Message)
+		return
+	}
+
+	l.logger.Log(entry.Level, entry.Err, entry.Fields, "%s", entry.Message)
+}
+
+// enabled reports whether messages with the level are written by the adapter. Fatal and Panic messages are always
+// enabled, so the logger exits or panics even when the adapter does not write them. Adapters without a level defer the
+// filtering to the adapter itself.
+func (l *Logger) enabled(level levels.Level) bool {
+	if level <= levels.Disabled {
+		return false
+	}
+
+	if level >= levels.Fatal {
+		return true
+	}
+
+	switch adapterLevel := l.logger.Level(); adapterLevel {
+	case levels.NoLevel:
+		return true
+	case levels.Disabled:
+		return false
+	default:
+		return level >= adapterLevel
+	}
+}
+
+// bufferTail keeps Debug and Trace entries of the logger execution on the tail buffer, and reports whether the
+// entry was buffered. Entries with Error level or above write the buffered entries of the execution first.
+func (l *Logger) bufferTail(entry *Entry) bool {
+	if l.tail == nil {
+		return false
+	}
+
+	key, ok := contextfields.ExecutionKey(l.ctx)
+	if !ok {
+		return false
+	}
+
+	if entry.Level < levels.Info {
+		if l.tail.Add(key, entry) && l.ctx.Done() != nil {
+			context.AfterFunc(l.ctx, func() {
+				l.tail.Discard(key)
+			})
+		}
+
+		return true
+	}
+
+	if entry.Level >= levels.Error {
+		for _, buffered := range l.tail.Drain(key) {
+			l.write(buffered)
+		}
+	}
+
+	return false
+}
+
+// discardTail removes the tail buffered entries of the logger execution.
+func (l *Logger) discardTail() {
+	if l.tail == nil {
+		return
+	}
+
+	if key, ok := contextfields.ExecutionKey(l.ctx); ok {
+		l.tail.Discard(key)
+	}
+}
+
+// sample reports whether the entry should be written according the configured sampler. When the entry is written
+// after other entries with the same level and message were discarded, a summary entry is written first. When it is
+// discarded, a summary is scheduled, so the discarded entries are reported even if no other entry is written.
+func (l *Logger) sample(level levels.Level, msg string) bool {
+	if l.sampler == nil || level >= levels.Error {
+		return true
+	}
+
+	keep, dropped := l.sampler.Sample(level, msg)
+	if !keep {
+		l.scheduleSummary(level, msg)
+		return false
+	}
+
+	if dropped > 0 {
+		l.logSummary(level, msg, dropped)
+	}
+
+	return true
+}
+
+// scheduleSummary writes the summary of the entries discarded by the sampler with the level and message once the
+// summary delay passes, unless a written entry already reported them.
+func (l *Logger) scheduleSummary(level levels.Level, msg string) {
+	key := samplerKey{level: level, msg: msg}
+
+	if !l.summaries.schedule(key) {
+		return
+	}
+
+	time.AfterFunc(l.summaries.delay, func() {
+		l.summaries.done(key)
+
+		if dropped := l.sampler.Dropped(level, msg); dropped > 0 {
+			l.logSummary(level, msg, dropped)
+		}
+	})
+}
+
+// logSummary writes the summary entry of the entries discarded by the sampler, with the logger and context fields.
+func (l *Logger) logSummary(level levels.Level, msg string, dropped uint64) {
+	format := "sampled out %d entries"
+	summary := fields.New().Set("sampled_message", msg).Set("sampled_count", dropped)
+
+	l.log(&Entry{
+		Time:    time.Now(),
+		Level:   level,
+		Message: fmt.Sprintf(format, dropped),
+		Format:  format,
+		Args:    []any{dropped},
+	}, summary, 0)
 }
@@ -0,0 +1,57 @@
+package logencoding
+
+import (
+	"github.com/danteay/golog/levels"
+)
+
+// ConsoleTimeFormat is the layout of the time on the human-readable console lines of the adapters.
+const ConsoleTimeFormat = "2006-01-02 15:04:05.000"
+
+// ANSI escape codes of the colors used on the console lines.
+const (
+	ColorReset   = "\x1b[0m"
+	ColorRed     = "\x1b[31m"
+	ColorGreen   = "\x1b[32m"
+	ColorYellow  = "\x1b[33m"
+	ColorBlue    = "\x1b[34m"
+	ColorMagenta = "\x1b[35m"
+	ColorGray    = "\x1b[90m"
+)
+
+// LevelAbbreviation returns the three letter name of the level written on the console lines, e.g. "INF".
+func LevelAbbreviation(level levels.Level) string {
+	switch level {
+	case levels.TraceLevel:
+		return "TRC"
+	case levels.Debug:
+		return "DBG"
+	case levels.Info:
+		return "INF"
+	case levels.Warn:
+		return "WRN"
+	case levels.Error:
+		return "ERR"
+	case levels.Fatal:
+		return "FTL"
+	case levels.Panic:
+		return "PNC"
+	default:
+		return "???"
+	}
+}
+
+// LevelColor returns the color of the level on the console lines.
+func LevelColor(level levels.Level) string {
+	switch level {
+	case levels.TraceLevel:
+		return ColorMagenta
+	case levels.Debug:
+		return ColorBlue
+	case levels.Info:
+		return ColorGreen
+	case levels.Warn:
+		return ColorYellow
+	default:
+		return ColorRed
+	}
+}
@@ -0,0 +1,44 @@
+package logencoding
+
+import (
+	"testing"
+
+	"github.com/danteay/golog/levels"
+)
+
+func TestLevelAbbreviation(t *testing.T) {
+	expected := map[levels.Level]string{
+		levels.TraceLevel: "TRC",
+		levels.Debug:      "DBG",
+		levels.Info:       "INF",
+		levels.Warn:       "WRN",
+		levels.Error:      "ERR",
+		levels.Fatal:      "FTL",
+		levels.Panic:      "PNC",
+		levels.NoLevel:    "???",
+	}
+
+	for level, abbreviation := range expected {
+		if got := LevelAbbreviation(level); got != abbreviation {
+			t.Errorf("Expected %q for %s, but got %q", abbreviation, level, got)
+		}
+	}
+}
+
+func TestLevelColor(t *testing.T) {
+	expected := map[levels.Level]string{
+		levels.TraceLevel: ColorMagenta,
+		levels.Debug:      ColorBlue,
+		levels.Info:       ColorGreen,
+		levels.Warn:       ColorYellow,
+		levels.Error:      ColorRed,
+		levels.Fatal:      ColorRed,
+		levels.Panic:      ColorRed,
+	}
+
+	for level, color := range expected {
+		if got := LevelColor(level); got != color {
+			t.Errorf("Expected %q for %s, but got %q", color, level, got)
+		}
+	}
+}
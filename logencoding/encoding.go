@@ -0,0 +1,143 @@
+// Package logencoding defines the key names, level casing and time format shared by the golog adapters, so every
+// adapter writes the same output shape when it is configured with the same options.
+package logencoding
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danteay/golog/levels"
+)
+
+const (
+	// DefaultTimeKey is the default key of the message time.
+	DefaultTimeKey = "time"
+	// DefaultLevelKey is the default key of the message level.
+	DefaultLevelKey = "level"
+	// DefaultMessageKey is the default key of the message text.
+	DefaultMessageKey = "message"
+	// DefaultErrorKey is the default key of the message error.
+	DefaultErrorKey = "error"
+	// DefaultStackKey is the default key of the message stack trace.
+	DefaultStackKey = "stack"
+)
+
+const (
+	// TimeFormatUnix writes the time as the number of seconds since the Unix epoch.
+	TimeFormatUnix = "UNIX"
+	// TimeFormatUnixMs writes the time as the number of milliseconds since the Unix epoch.
+	TimeFormatUnixMs = "UNIXMS"
+	// TimeFormatUnixMicro writes the time as the number of microseconds since the Unix epoch.
+	TimeFormatUnixMicro = "UNIXMICRO"
+	// TimeFormatUnixNano writes the time as the number of nanoseconds since the Unix epoch.
+	TimeFormatUnixNano = "UNIXNANO"
+)
+
+// Case defines the casing of the level values.
+type Case int
+
+const (
+	// Lowercase writes the level values in lower case, e.g. "info".
+	Lowercase Case = iota
+	// Uppercase writes the level values in upper case, e.g. "INFO".
+	Uppercase
+)
+
+// Config holds the encoding settings of an adapter.
+type Config struct {
+	TimeKey    string
+	LevelKey   string
+	MessageKey string
+	ErrorKey   string
+	StackKey   string
+	LevelCase  Case
+	// TimeFormat is a time layout, e.g. time.RFC3339Nano, or one of the TimeFormatUnix constants.
+	TimeFormat string
+	// UTC converts the message times to UTC before formatting them.
+	UTC bool
+}
+
+// New creates a Config with the default settings, that can be changed with the provided options. The defaults are the
+// "time", "level", "message", "error" and "stack" keys, lower case levels and RFC3339 times with nanoseconds.
+func New(opts ...Option) Config {
+	config := Config{
+		TimeKey:    DefaultTimeKey,
+		LevelKey:   DefaultLevelKey,
+		MessageKey: DefaultMessageKey,
+		ErrorKey:   DefaultErrorKey,
+		StackKey:   DefaultStackKey,
+		LevelCase:  Lowercase,
+		TimeFormat: time.RFC3339Nano,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return config
+}
+
+// Level returns the value of the level with the configured casing.
+func (c Config) Level(level levels.Level) string {
+	if c.LevelCase == Uppercase {
+		return strings.ToUpper(level.String())
+	}
+
+	return level.String()
+}
+
+// IsNumericTime reports whether the times are written as numbers since the Unix epoch.
+func (c Config) IsNumericTime() bool {
+	switch c.TimeFormat {
+	case TimeFormatUnix, TimeFormatUnixMs, TimeFormatUnixMicro, TimeFormatUnixNano:
+		return true
+	default:
+		return false
+	}
+}
+
+// Time returns the value of the time, that is an int64 for the Unix formats and a string for the rest of the layouts.
+func (c Config) Time(t time.Time) any {
+	if c.IsNumericTime() {
+		return c.unixTime(t)
+	}
+
+	return c.FormatTime(t)
+}
+
+// FormatTime returns the time formatted as a string.
+func (c Config) FormatTime(t time.Time) string {
+	return string(c.AppendTime(nil, t))
+}
+
+// AppendTime appends the formatted time to buf, without quotes.
+func (c Config) AppendTime(buf []byte, t time.Time) []byte {
+	if c.IsNumericTime() {
+		return strconv.AppendInt(buf, c.unixTime(t), 10)
+	}
+
+	if c.UTC {
+		t = t.UTC()
+	}
+
+	layout := c.TimeFormat
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+
+	return t.AppendFormat(buf, layout)
+}
+
+func (c Config) unixTime(t time.Time) int64 {
+	switch c.TimeFormat {
+	case TimeFormatUnix:
+		return t.Unix()
+	case TimeFormatUnixMs:
+		return t.UnixMilli()
+	case TimeFormatUnixMicro:
+		return t.UnixMicro()
+	default:
+		return t.UnixNano()
+	}
+}
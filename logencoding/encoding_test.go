@@ -0,0 +1,121 @@
+package logencoding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danteay/golog/levels"
+)
+
+func TestNew(t *testing.T) {
+	config := New()
+
+	expected := Config{
+		TimeKey:    "time",
+		LevelKey:   "level",
+		MessageKey: "message",
+		ErrorKey:   "error",
+		StackKey:   "stack",
+		LevelCase:  Lowercase,
+		TimeFormat: time.RFC3339Nano,
+	}
+
+	if config != expected {
+		t.Errorf("Expected default config %+v, but got %+v", expected, config)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	config := New(
+		WithTimeKey("ts"),
+		WithLevelKey("severity"),
+		WithMessageKey("msg"),
+		WithErrorKey("err"),
+		WithStackKey("trace"),
+		WithLevelCase(Uppercase),
+		WithTimeFormat(TimeFormatUnixMs),
+		WithUTC(),
+	)
+
+	expected := Config{
+		TimeKey:    "ts",
+		LevelKey:   "severity",
+		MessageKey: "msg",
+		ErrorKey:   "err",
+		StackKey:   "trace",
+		LevelCase:  Uppercase,
+		TimeFormat: TimeFormatUnixMs,
+		UTC:        true,
+	}
+
+	if config != expected {
+		t.Errorf("Expected config %+v, but got %+v", expected, config)
+	}
+}
+
+func TestConfig_Level(t *testing.T) {
+	tests := []struct {
+		levelCase Case
+		level     levels.Level
+		expected  string
+	}{
+		{Lowercase, levels.Info, "info"},
+		{Lowercase, levels.TraceLevel, "trace"},
+		{Uppercase, levels.Warn, "WARN"},
+		{Uppercase, levels.Fatal, "FATAL"},
+	}
+
+	for _, test := range tests {
+		config := New(WithLevelCase(test.levelCase))
+
+		if got := config.Level(test.level); got != test.expected {
+			t.Errorf("Expected level %q, but got %q", test.expected, got)
+		}
+	}
+}
+
+func TestConfig_Time(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.FixedZone("CST", -6*60*60))
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected any
+	}{
+		{name: "default", expected: "2024-01-02T03:04:05.006-06:00"},
+		{name: "utc", opts: []Option{WithUTC()}, expected: "2024-01-02T09:04:05.006Z"},
+		{name: "layout", opts: []Option{WithTimeFormat(time.DateTime)}, expected: "2024-01-02 03:04:05"},
+		{name: "unix", opts: []Option{WithTimeFormat(TimeFormatUnix)}, expected: ts.Unix()},
+		{name: "unix ms", opts: []Option{WithTimeFormat(TimeFormatUnixMs)}, expected: ts.UnixMilli()},
+		{name: "unix micro", opts: []Option{WithTimeFormat(TimeFormatUnixMicro)}, expected: ts.UnixMicro()},
+		{name: "unix nano", opts: []Option{WithTimeFormat(TimeFormatUnixNano)}, expected: ts.UnixNano()},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			config := New(test.opts...)
+
+			if got := config.Time(ts); got != test.expected {
+				t.Errorf("Expected time %v, but got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestConfig_AppendTime(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	config := New(WithTimeFormat(TimeFormatUnixMs))
+
+	if got := string(config.AppendTime([]byte("t="), ts)); got != "t=1704164645000" {
+		t.Errorf("Expected appended unix time, but got %q", got)
+	}
+
+	config = New(WithTimeFormat(time.RFC3339))
+
+	if got := config.FormatTime(ts); got != "2024-01-02T03:04:05Z" {
+		t.Errorf("Expected formatted time, but got %q", got)
+	}
+}
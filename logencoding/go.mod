@@ -0,0 +1,8 @@
+module github.com/danteay/golog/logencoding
+
+go 1.21
+
+require (
+	github.com/danteay/golog/fields v0.1.0
+	github.com/danteay/golog/levels v0.1.1
+)
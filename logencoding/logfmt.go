@@ -0,0 +1,69 @@
+package logencoding
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// AppendLogfmtKey appends key as a logfmt key, replacing the characters that are not allowed on logfmt keys (spaces,
+// control characters, equal signs and quotes) with underscores. Empty keys are written as an underscore.
+func AppendLogfmtKey(buf []byte, key string) []byte {
+	if key == "" {
+		return append(buf, '_')
+	}
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !isLogfmtSafe(c) {
+			c = '_'
+		}
+
+		buf = append(buf, c)
+	}
+
+	return buf
+}
+
+// LogfmtKey returns key as a logfmt key, with the same replacements as AppendLogfmtKey.
+func LogfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	for i := 0; i < len(key); i++ {
+		if !isLogfmtSafe(key[i]) {
+			return string(AppendLogfmtKey(make([]byte, 0, len(key)), key))
+		}
+	}
+
+	return key
+}
+
+// AppendLogfmtValue appends s as a logfmt value, quoted if it is empty, is not valid UTF-8 or contains spaces, quotes,
+// equal signs or control characters.
+func AppendLogfmtValue(buf []byte, s string) []byte {
+	if !NeedsLogfmtQuote(s) {
+		return append(buf, s...)
+	}
+
+	return strconv.AppendQuote(buf, s)
+}
+
+// NeedsLogfmtQuote reports whether s must be quoted to be written as a logfmt value.
+func NeedsLogfmtQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isLogfmtSafe(s[i]) {
+			return true
+		}
+	}
+
+	return !utf8.ValidString(s)
+}
+
+func isLogfmtSafe(c byte) bool {
+	return c > ' ' && c != '=' && c != '"' && c != 0x7f
+}
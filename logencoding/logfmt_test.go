@@ -0,0 +1,56 @@
+package logencoding
+
+import "testing"
+
+func TestLogfmtKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{key: "user", expected: "user"},
+		{key: "", expected: "_"},
+		{key: "some key", expected: "some_key"},
+		{key: "a=b\"c\n", expected: "a_b_c_"},
+		{key: "usuário", expected: "usuário"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.key, func(t *testing.T) {
+			if got := LogfmtKey(test.key); got != test.expected {
+				t.Errorf("LogfmtKey(%q) = %q, expected %q", test.key, got, test.expected)
+			}
+
+			if got := string(AppendLogfmtKey([]byte("k="), test.key)); got != "k="+test.expected {
+				t.Errorf("AppendLogfmtKey(%q) = %q, expected %q", test.key, got, "k="+test.expected)
+			}
+		})
+	}
+}
+
+func TestAppendLogfmtValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "value", expected: "value"},
+		{value: "", expected: `""`},
+		{value: "some value", expected: `"some value"`},
+		{value: "a=b", expected: `"a=b"`},
+		{value: `say "hi"`, expected: `"say \"hi\""`},
+		{value: "line\nbreak", expected: `"line\nbreak"`},
+		{value: "usuário", expected: "usuário"},
+		{value: "\xff", expected: `"\xff"`},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.value, func(t *testing.T) {
+			if got := string(AppendLogfmtValue(nil, test.value)); got != test.expected {
+				t.Errorf("AppendLogfmtValue(%q) = %s, expected %s", test.value, got, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package logencoding
+
+// Option defines the signature for the options.
+type Option func(*Config)
+
+// WithTimeKey sets the key of the message time.
+func WithTimeKey(key string) Option {
+	return func(c *Config) {
+		c.TimeKey = key
+	}
+}
+
+// WithLevelKey sets the key of the message level.
+func WithLevelKey(key string) Option {
+	return func(c *Config) {
+		c.LevelKey = key
+	}
+}
+
+// WithMessageKey sets the key of the message text.
+func WithMessageKey(key string) Option {
+	return func(c *Config) {
+		c.MessageKey = key
+	}
+}
+
+// WithErrorKey sets the key of the message error.
+func WithErrorKey(key string) Option {
+	return func(c *Config) {
+		c.ErrorKey = key
+	}
+}
+
+// WithStackKey sets the key of the message stack trace.
+func WithStackKey(key string) Option {
+	return func(c *Config) {
+		c.StackKey = key
+	}
+}
+
+// WithLevelCase sets the casing of the level values.
+func WithLevelCase(levelCase Case) Option {
+	return func(c *Config) {
+		c.LevelCase = levelCase
+	}
+}
+
+// WithTimeFormat sets the format of the message time, that can be a time layout, e.g. time.RFC3339, or one of the
+// TimeFormatUnix constants.
+func WithTimeFormat(format string) Option {
+	return func(c *Config) {
+		c.TimeFormat = format
+	}
+}
+
+// WithUTC converts the message times to UTC before formatting them.
+func WithUTC() Option {
+	return func(c *Config) {
+		c.UTC = true
+	}
+}
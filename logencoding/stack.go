@@ -0,0 +1,31 @@
+package logencoding
+
+import (
+	"runtime/debug"
+	"strings"
+
+	"github.com/danteay/golog/fields"
+)
+
+// HasStack reports whether the fields carry a stack trace under any of the given keys, e.g. the one a golog.Logger
+// adds to the messages with an error, so the adapters do not write a second one.
+func HasStack(logFields *fields.Fields, keys ...string) bool {
+	if logFields == nil {
+		return false
+	}
+
+	for _, key := range keys {
+		if logFields.Get(key) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StackTrace returns the stack trace of the calling goroutine, one line per element, that the adapters write on the
+// messages with an error when the trace is enabled.
+func StackTrace() []string {
+	stack := strings.ReplaceAll(string(debug.Stack()), "\t", "")
+	return strings.Split(stack, "\n")
+}
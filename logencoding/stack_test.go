@@ -0,0 +1,59 @@
+package logencoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/danteay/golog/fields"
+)
+
+func TestHasStack(t *testing.T) {
+	tests := []struct {
+		name      string
+		logFields *fields.Fields
+		keys      []string
+		expected  bool
+	}{
+		{
+			name:     "should report nil fields without stack",
+			keys:     []string{DefaultStackKey},
+			expected: false,
+		},
+		{
+			name:      "should find the stack on the key",
+			logFields: fields.New().Set("trace", []string{"frame"}),
+			keys:      []string{DefaultStackKey, "trace"},
+			expected:  true,
+		},
+		{
+			name:      "should not find the stack on other keys",
+			logFields: fields.New().Set("trace", []string{"frame"}),
+			keys:      []string{DefaultStackKey},
+			expected:  false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if got := HasStack(test.logFields, test.keys...); got != test.expected {
+				t.Errorf("Expected %v, but got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	stack := StackTrace()
+
+	if !strings.Contains(strings.Join(stack, "\n"), "TestStackTrace") {
+		t.Errorf("Expected the stack trace to contain the caller, but got %v", stack)
+	}
+
+	for _, line := range stack {
+		if strings.Contains(line, "\t") {
+			t.Errorf("Expected the stack trace lines without tabs, but got %q", line)
+		}
+	}
+}
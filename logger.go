@@ -3,13 +3,20 @@ package golog
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/danteay/golog/adapters/slog"
 	"github.com/danteay/golog/fields"
 	"github.com/danteay/golog/internal/contextfields"
+	"github.com/danteay/golog/internal/tailbuffer"
 	"github.com/danteay/golog/levels"
 )
 
+// maxTailExecutions is the maximum number of executions with tail buffered messages. When it is reached, the buffer of
+// the execution that logged least recently is discarded, so executions whose context is never done and that are not
+// flushed do not grow the memory without limit when no context fields ttl is set.
+const maxTailExecutions = 10000
+
 // Adapter is the interface that wraps the Log method.
 type Adapter interface {
 	Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
@@ -19,12 +26,37 @@ type Adapter interface {
 	SetLevel(level levels.Level)
 }
 
+// TimedAdapter is implemented by adapters that can log a message with a given timestamp instead of the current time.
+// When the adapter implements it, log messages are written with the time they were logged on the Logger, which is
+// needed to keep the original timestamps of buffered messages.
+type TimedAdapter interface {
+	LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any)
+}
+
+// EntryAdapter is implemented by adapters that take the whole entry, with the format string and the arguments of the
+// message, e.g. to record them on tests. When the adapter implements it, entries are written with LogEntry after the
+// hooks run. Fatal and Panic entries must be written without exiting or panicking, as the Logger does it afterwards.
+type EntryAdapter interface {
+	LogEntry(entry *Entry)
+}
+
 // Logger is the main struct that holds the logger instance.
+//
+// A Logger is immutable once created. Every method that attaches data to it (With, Field, Fields, Err and
+// SetContext) returns a new child Logger with its own copy of the fields, so a single instance can be safely
+// shared across goroutines.
 type Logger struct {
-	ctx    context.Context
-	logger Adapter
-	fields *fields.Fields
-	err    error
+	ctx        context.Context
+	logger     Adapter
+	hooks      []Hook
+	sampler    Sampler
+	summaries  *samplingSummaries
+	tail       *tailbuffer.Store[*Entry]
+	exit       *exitHandler
+	caller     bool
+	callerSkip int
+	fields     *fields.Fields
+	err        error
 }
 
 var _ io.Writer = (*Logger)(nil)
@@ -33,43 +65,80 @@ var _ io.Writer = (*Logger)(nil)
 // If no options are provided, the default options will be used (level: Info, colored: false).
 func New(opts ...Option) *Logger {
 	logOpts := options{
-		adapter: slog.New(),
+		adapter:  slog.New(),
+		exitCode: DefaultExitCode,
 	}
 
 	for _, opt := range opts {
 		opt(&logOpts)
 	}
 
-	return &Logger{
-		ctx:    context.Background(),
-		fields: fields.New(),
-		logger: logOpts.adapter,
+	logger := &Logger{
+		ctx:        context.Background(),
+		fields:     fields.New(),
+		logger:     logOpts.adapter,
+		hooks:      logOpts.hooks,
+		sampler:    logOpts.sampler,
+		exit:       newExitHandler(logOpts),
+		caller:     logOpts.caller,
+		callerSkip: logOpts.callerSkip,
+	}
+
+	if logOpts.sampler != nil {
+		logger.summaries = newSamplingSummaries()
+	}
+
+	if logOpts.tailSize > 0 {
+		logger.tail = tailbuffer.New[*Entry](logOpts.tailSize, maxTailExecutions, contextfields.TTL)
 	}
+
+	return logger
 }
 
-// SetContext sets the context to be used in the logger instance to identify and group log fields by execution
+// SetContext returns a child logger that uses the provided context to identify and group log fields by execution.
 func (l *Logger) SetContext(ctx context.Context) *Logger {
 	if ctx == nil {
 		return l
 	}
 
-	l.ctx = ctx
-	return l
+	child := l.clone()
+	child.ctx = ctx
+
+	return child
 }
 
-// Field adds a field to the logger instance.
+// With returns a child logger that carries the provided fields on every log message. The parent logger is not
+// modified.
+func (l *Logger) With(fields map[string]any) *Logger {
+	child := l.clone()
+	child.fields.SetMap(fields)
+
+	return child
+}
+
+// Field returns a child logger with the provided field added. It is meant to be chained with a log call to add
+// one-off fields to a single message, e.g. logger.Field("key", "value").Info("message").
 func (l *Logger) Field(key string, value any) *Logger {
-	l.fields.Set(key, value)
-	return l
+	child := l.clone()
+	child.fields.Set(key, value)
+
+	return child
 }
 
-// Fields adds multiple fields to the logger instance.
+// Fields returns a child logger with the provided fields added. It is meant to be chained with a log call to add
+// one-off fields to a single message.
 func (l *Logger) Fields(fields map[string]any) *Logger {
-	for k, v := range fields {
-		l.fields.Set(k, v)
-	}
+	return l.With(fields)
+}
 
-	return l
+// WithFields returns a child logger with the provided typed fields added, e.g.
+// logger.WithFields(fields.Int64("attempt", 3), fields.Duration("elapsed", elapsed)).Info("message"). The values are
+// not boxed on interfaces, so adapters encode them with their typed methods.
+func (l *Logger) WithFields(fs ...fields.Field) *Logger {
+	child := l.clone()
+	child.fields.SetFields(fs...)
+
+	return child
 }
 
 // SetContextFields sets fields that should be printed in every log message.
@@ -78,19 +147,40 @@ func (l *Logger) SetContextFields(fields map[string]any) *Logger {
 	return l
 }
 
-// FlushContextFields removes all context fields from the logger instance.
+// FlushContextFields removes all context fields from the logger instance. When tail buffering is enabled, it also
+// discards the buffered messages of the logger execution.
 func (l *Logger) FlushContextFields() *Logger {
 	contextfields.Flush(l.ctx)
+	l.discardTail()
+
 	return l
 }
 
-// Err sets the error to be logged.
-func (l *Logger) Err(err error) *Logger {
-	l.err = err
+// FlushContextFieldsOnDone removes the context fields of the logger execution once its context is done.
+func (l *Logger) FlushContextFieldsOnDone() *Logger {
+	contextfields.FlushOnDone(l.ctx)
 	return l
 }
 
-// Write user the writer configured o the adapter to write the logs.
+// Err returns a child logger with the error to be logged.
+func (l *Logger) Err(err error) *Logger {
+	child := l.clone()
+	child.err = err
+
+	return child
+}
+
+// CallerSkip returns a child logger that skips skip additional stack frames when capturing the caller, so functions
+// that wrap the logger report the location of their own callers. The skip is added to the one of the parent logger.
+func (l *Logger) CallerSkip(skip int) *Logger {
+	child := l.clone()
+	child.callerSkip += skip
+
+	return child
+}
+
+// Write user the writer configured o the adapter to write the logs. The bytes are written as they are; to log
+// every written line as a structured entry use a LineWriter instead.
 func (l *Logger) Write(p []byte) (n int, err error) {
 	return l.logger.Writer().Write(p)
 }
@@ -114,3 +204,19 @@ func (l *Logger) Level() levels.Level {
 func (l *Logger) SetLevel(level levels.Level) {
 	l.logger.SetLevel(level)
 }
+
+func (l *Logger) clone() *Logger {
+	return &Logger{
+		ctx:        l.ctx,
+		logger:     l.logger,
+		hooks:      l.hooks,
+		sampler:    l.sampler,
+		summaries:  l.summaries,
+		tail:       l.tail,
+		exit:       l.exit,
+		caller:     l.caller,
+		callerSkip: l.callerSkip,
+		fields:     l.fields.Copy(),
+		err:        l.err,
+	}
+}
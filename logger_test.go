@@ -5,24 +5,28 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/fields"
 	"github.com/danteay/golog/internal/contextfields"
 	"github.com/danteay/golog/levels"
 )
 
 type testMsg struct {
-	Level   string   `json:"level"`
-	Message string   `json:"msg"`
-	Stack   []string `json:"stack"`
-	Error   string   `json:"error"`
-	Key1    string   `json:"key1"`
-	Key2    int      `json:"key2"`
-	CtxKey  string   `json:"ctx_key"`
+	Level   string  `json:"level"`
+	Message string  `json:"message"`
+	Stack   []Frame `json:"stack"`
+	Error   string  `json:"error"`
+	Key1    string  `json:"key1"`
+	Key2    int     `json:"key2"`
+	CtxKey  string  `json:"ctx_key"`
 }
 
 func TestNewLogger(t *testing.T) {
@@ -44,13 +48,47 @@ func TestLoggerFields(t *testing.T) {
 		"key2": 42,
 	}
 
-	logger.Fields(fields)
+	child := logger.Fields(fields)
 
 	for key, value := range fields {
-		if fieldValue := logger.fields.Get(key); fieldValue != value {
+		if fieldValue := child.fields.Get(key); fieldValue != value {
 			t.Errorf("Expected field %s to be %v, but got %v", key, value, fieldValue)
 		}
 	}
+
+	assert.True(t, logger.fields.IsEmpty())
+}
+
+func TestLoggerWith(t *testing.T) {
+	logger := New()
+
+	child := logger.With(map[string]any{"key1": "value1"})
+	grandChild := child.With(map[string]any{"key2": 42})
+
+	assert.NotSame(t, logger, child)
+	assert.True(t, logger.fields.IsEmpty())
+	assert.Equal(t, map[string]any{"key1": "value1"}, child.fields.Data())
+	assert.Equal(t, map[string]any{"key1": "value1", "key2": 42}, grandChild.fields.Data())
+}
+
+func TestLoggerWithFields(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+	child := logger.WithFields(fields.String("key1", "value1"), fields.Int64("key2", 42))
+	child.WithFields(fields.Duration("elapsed", time.Second), fields.Bytes("body", []byte("data"))).Info("Test message")
+
+	assert.True(t, logger.fields.IsEmpty())
+	assert.Equal(t, map[string]any{"key1": "value1", "key2": int64(42)}, child.fields.Data())
+
+	res := map[string]any{}
+	assert.NoError(t, json.Unmarshal(logOutput.Bytes(), &res))
+
+	assert.Equal(t, "value1", res["key1"])
+	assert.Equal(t, float64(42), res["key2"])
+	assert.Equal(t, float64(time.Second), res["elapsed"])
+	assert.Equal(t, "data", res["body"])
 }
 
 func TestLoggerErr(t *testing.T) {
@@ -58,15 +96,57 @@ func TestLoggerErr(t *testing.T) {
 
 	err := errors.New("test error")
 
-	logger.Err(err)
+	child := logger.Err(err)
 
-	if logger.err == nil {
+	if child.err == nil {
 		t.Error("Expected logger error to be non-nil, but it's nil")
 	}
 
-	if !errors.Is(err, logger.err) {
-		t.Errorf("Expected logger error to be %v, but got %v", err, logger.err)
+	if !errors.Is(err, child.err) {
+		t.Errorf("Expected logger error to be %v, but got %v", err, child.err)
 	}
+
+	assert.Nil(t, logger.err)
+}
+
+func TestLoggerConcurrentUse(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+	logger := New(WithAdapter(adapter)).With(map[string]any{"shared": "value"})
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			logger.Field("goroutine", i).Err(errors.New("test error")).Info("Test message")
+		}(i)
+	}
+
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(logOutput.String()), "\n")
+	assert.Len(t, lines, goroutines)
+
+	seen := make(map[int]bool)
+
+	for _, line := range lines {
+		res := map[string]any{}
+
+		if errUnmarshal := json.Unmarshal([]byte(line), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, "value", res["shared"])
+		seen[int(res["goroutine"].(float64))] = true
+	}
+
+	assert.Len(t, seen, goroutines)
+	assert.Equal(t, map[string]any{"shared": "value"}, logger.fields.Data())
+	assert.Nil(t, logger.err)
 }
 
 func TestLoggerLog(t *testing.T) {
@@ -165,3 +245,296 @@ func TestLoggerLog(t *testing.T) {
 		assert.Empty(t, res.Stack)
 	})
 }
+
+func TestLoggerHooks(t *testing.T) {
+	t.Run("should modify entries in order", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+
+		addField := func(entry *Entry) bool {
+			entry.Fields.Set("key1", "value1")
+			return true
+		}
+
+		overrideField := func(entry *Entry) bool {
+			entry.Fields.Set("key1", entry.Fields.Get("key1").(string)+"-override")
+			entry.Message = strings.ToUpper(entry.Message)
+			entry.Level = levels.Warn
+			return true
+		}
+
+		logger := New(WithAdapter(adapter), WithHooks(addField, overrideField))
+
+		logger.Info("Test message %d%%", 100)
+
+		res := testMsg{}
+
+		if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, levels.Warn.String(), strings.ToLower(res.Level))
+		assert.Equal(t, "TEST MESSAGE 100%", res.Message)
+		assert.Equal(t, "value1-override", res.Key1)
+	})
+
+	t.Run("should receive entry data", func(t *testing.T) {
+		var entry Entry
+
+		err := errors.New("test error")
+		before := time.Now()
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(io.Discard))), WithHooks(func(e *Entry) bool {
+			entry = *e
+			return true
+		}))
+
+		logger.Field("key1", "value1").Err(err).Error("Test %s", "message")
+
+		assert.Equal(t, levels.Error, entry.Level)
+		assert.Equal(t, "Test message", entry.Message)
+		assert.Equal(t, err, entry.Err)
+		assert.Equal(t, "value1", entry.Fields.Get("key1"))
+		assert.False(t, entry.Time.Before(before))
+	})
+
+	t.Run("should drop entries", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		called := false
+
+		drop := func(entry *Entry) bool {
+			return entry.Message != "health check"
+		}
+
+		after := func(_ *Entry) bool {
+			called = true
+			return true
+		}
+
+		logger := New(WithAdapter(adapter), WithHooks(drop, after))
+
+		logger.Info("health check")
+
+		assert.Empty(t, logOutput.String())
+		assert.False(t, called)
+
+		logger.Info("Test message")
+
+		assert.NotEmpty(t, logOutput.String())
+		assert.True(t, called)
+	})
+}
+
+func TestLoggerTailBuffer(t *testing.T) {
+	decode := func(t *testing.T, output *bytes.Buffer) []map[string]any {
+		t.Helper()
+
+		if output.Len() == 0 {
+			return nil
+		}
+
+		var msgs []map[string]any
+
+		for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+			res := map[string]any{}
+
+			if errUnmarshal := json.Unmarshal([]byte(line), &res); errUnmarshal != nil {
+				t.Fatal(errUnmarshal)
+			}
+
+			msgs = append(msgs, res)
+		}
+
+		return msgs
+	}
+
+	t.Run("should flush buffered entries on error", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithTailBuffer(10)).SetContext(NewExecution(context.Background(), "some-exec-id"))
+		defer logger.FlushContextFields()
+
+		logger.Debug("debug message 1")
+		time.Sleep(time.Millisecond)
+		logger.Debug("debug message 2")
+		logger.Info("info message")
+
+		msgs := decode(t, &logOutput)
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, "info message", msgs[0]["message"])
+
+		logOutput.Reset()
+
+		logger.Error("error message")
+
+		msgs = decode(t, &logOutput)
+		assert.Len(t, msgs, 3)
+		assert.Equal(t, "debug message 1", msgs[0]["message"])
+		assert.Equal(t, "debug message 2", msgs[1]["message"])
+		assert.Equal(t, "error message", msgs[2]["message"])
+		assert.Less(t, msgs[0]["time"], msgs[1]["time"])
+
+		logOutput.Reset()
+
+		logger.Error("error message")
+
+		assert.Len(t, decode(t, &logOutput), 1)
+	})
+
+	t.Run("should discard buffered entries on flush", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithTailBuffer(10)).SetContext(NewExecution(context.Background(), "some-exec-id"))
+
+		logger.Debug("debug message")
+		logger.FlushContextFields()
+		logger.Error("error message")
+
+		msgs := decode(t, &logOutput)
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, "error message", msgs[0]["message"])
+	})
+
+	t.Run("should discard buffered entries when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(NewExecution(context.Background(), "some-exec-id"))
+
+		adapter := slog.New(slog.WithWriter(io.Discard), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithTailBuffer(10)).SetContext(ctx)
+
+		logger.Debug("debug message")
+		assert.Equal(t, 1, logger.tail.Executions())
+
+		cancel()
+
+		assert.Eventually(t, func() bool {
+			return logger.tail.Executions() == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should evict buffered entries after the context fields ttl", func(t *testing.T) {
+		SetContextFieldsTTL(10 * time.Millisecond)
+		defer SetContextFieldsTTL(0)
+
+		adapter := slog.New(slog.WithWriter(io.Discard), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithTailBuffer(10)).SetContext(NewExecution(context.Background(), "some-exec-id"))
+
+		logger.Debug("debug message")
+		assert.Equal(t, 1, logger.tail.Executions())
+
+		assert.Eventually(t, func() bool {
+			return logger.tail.Executions() == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should not buffer entries without execution", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithTailBuffer(10))
+
+		logger.Debug("debug message")
+
+		assert.Len(t, decode(t, &logOutput), 1)
+	})
+
+	t.Run("should group buffered entries by execution", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithTailBuffer(10))
+
+		exec1 := logger.SetContext(NewExecution(context.Background(), "exec1"))
+		exec2 := logger.SetContext(NewExecution(context.Background(), "exec2"))
+		defer exec1.FlushContextFields()
+		defer exec2.FlushContextFields()
+
+		exec1.Debug("exec1 debug message")
+		exec2.Debug("exec2 debug message")
+		exec2.Error("exec2 error message")
+
+		msgs := decode(t, &logOutput)
+		assert.Len(t, msgs, 2)
+		assert.Equal(t, "exec2 debug message", msgs[0]["message"])
+		assert.Equal(t, "exec2", msgs[0]["execution_id"])
+		assert.Equal(t, "exec2 error message", msgs[1]["message"])
+	})
+}
+
+type countingStringer struct {
+	calls *int
+}
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "value"
+}
+
+func TestLoggerLevelGating(t *testing.T) {
+	t.Run("should not evaluate lazy fields and arguments of disabled levels", func(t *testing.T) {
+		var (
+			logOutput bytes.Buffer
+			calls     int
+		)
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Info))))
+
+		lazy := fields.Lazy(func() any {
+			calls++
+			return "computed"
+		})
+
+		logger.Field("lazy", lazy).Debug("debug message %s", countingStringer{calls: &calls})
+
+		assert.Zero(t, calls)
+		assert.Empty(t, logOutput.String())
+	})
+
+	t.Run("should evaluate lazy fields once for enabled levels", func(t *testing.T) {
+		var (
+			logOutput bytes.Buffer
+			calls     int
+			hooked    any
+		)
+
+		logger := New(
+			WithAdapter(slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Info))),
+			WithHooks(func(entry *Entry) bool {
+				hooked = entry.Fields.Get("lazy")
+				return true
+			}),
+		)
+
+		logger.Field("lazy", fields.Lazy(func() any {
+			calls++
+			return "computed"
+		})).Info("info message")
+
+		res := map[string]any{}
+		if err := json.Unmarshal(logOutput.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "computed", hooked)
+		assert.Equal(t, "computed", res["lazy"])
+	})
+	t.Run("should defer the filtering to adapters without level", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.NoLevel))))
+
+		logger.Debug("debug message")
+		assert.Empty(t, logOutput.String())
+
+		logger.Info("info message")
+		assert.Equal(t, "info message", decodeMessage(t, &logOutput)["message"])
+
+		logger.Error("error message")
+		assert.Equal(t, "error message", decodeMessage(t, &logOutput)["message"])
+	})
+}
@@ -0,0 +1,151 @@
+package logtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/danteay/golog/levels"
+)
+
+// ByLevel returns a Filter function that matches the entries with the given level.
+func ByLevel(level levels.Level) func(Entry) bool {
+	return func(entry Entry) bool {
+		return entry.Level == level
+	}
+}
+
+// ByMessage returns a Filter function that matches the entries with the given formatted message.
+func ByMessage(msg string) func(Entry) bool {
+	return func(entry Entry) bool {
+		return entry.Message == msg
+	}
+}
+
+// AssertLogged checks that a message with the given level and formatted message was recorded by the logger created
+// with NewLogger for the test, with the fields provided as key-value pairs, e.g.
+// logtest.AssertLogged(t, levels.Error, "request failed", "status", 500). Entries with more fields than the provided
+// ones also match. It reports an error on t when there is no match, and returns whether the assertion succeeded.
+func AssertLogged(t testing.TB, level levels.Level, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	adapter, ok := adapterOf(t)
+	if !ok {
+		t.Errorf("logtest: no logger was created with NewLogger for the test")
+		return false
+	}
+
+	return adapter.AssertLogged(t, level, msg, keysAndValues...)
+}
+
+// AssertNotLogged checks that no message with the given level, formatted message and fields was recorded by the
+// logger created with NewLogger for the test. It is the opposite of AssertLogged.
+func AssertNotLogged(t testing.TB, level levels.Level, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	adapter, ok := adapterOf(t)
+	if !ok {
+		t.Errorf("logtest: no logger was created with NewLogger for the test")
+		return false
+	}
+
+	return adapter.AssertNotLogged(t, level, msg, keysAndValues...)
+}
+
+// AssertLogged checks that a message with the given level and formatted message was recorded, with the fields
+// provided as key-value pairs, e.g. AssertLogged(t, levels.Error, "request failed", "status", 500). Entries with
+// more fields than the provided ones also match. It reports an error on t with the recorded entries when there is
+// no match, and returns whether the assertion succeeded.
+func (a *Adapter) AssertLogged(t testing.TB, level levels.Level, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	expected, err := pairs(keysAndValues)
+	if err != nil {
+		t.Errorf("logtest: %v", err)
+		return false
+	}
+
+	if len(a.Filter(matches(level, msg, expected))) > 0 {
+		return true
+	}
+
+	t.Errorf("logtest: expected a %q message %q with fields %v, but it was not logged%s",
+		level, msg, expected, a.recorded())
+
+	return false
+}
+
+// AssertNotLogged checks that no message with the given level, formatted message and fields was recorded. It is the
+// opposite of AssertLogged.
+func (a *Adapter) AssertNotLogged(t testing.TB, level levels.Level, msg string, keysAndValues ...any) bool {
+	t.Helper()
+
+	expected, err := pairs(keysAndValues)
+	if err != nil {
+		t.Errorf("logtest: %v", err)
+		return false
+	}
+
+	found := a.Filter(matches(level, msg, expected))
+	if len(found) == 0 {
+		return true
+	}
+
+	t.Errorf("logtest: expected no %q message %q with fields %v, but it was logged: %s", level, msg, expected, found[0])
+
+	return false
+}
+
+func matches(level levels.Level, msg string, expected map[string]any) func(Entry) bool {
+	return func(entry Entry) bool {
+		if entry.Level != level || entry.Message != msg {
+			return false
+		}
+
+		for key, value := range expected {
+			actual, exists := entry.Fields[key]
+			if !exists || !reflect.DeepEqual(actual, value) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func pairs(keysAndValues []any) (map[string]any, error) {
+	if len(keysAndValues)%2 != 0 {
+		return nil, fmt.Errorf("odd number of key-value arguments: %v", keysAndValues)
+	}
+
+	fieldPairs := make(map[string]any, len(keysAndValues)/2)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("field key %v is not a string", keysAndValues[i])
+		}
+
+		fieldPairs[key] = keysAndValues[i+1]
+	}
+
+	return fieldPairs, nil
+}
+
+func (a *Adapter) recorded() string {
+	entries := a.Entries()
+	if len(entries) == 0 {
+		return "; no messages were recorded"
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("; recorded messages:")
+
+	for _, entry := range entries {
+		sb.WriteString("\n\t" + entry.String())
+	}
+
+	return sb.String()
+}
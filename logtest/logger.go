@@ -0,0 +1,46 @@
+package logtest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/danteay/golog"
+)
+
+// adapters holds the adapters created with NewLogger by test, so the package assertions can find them.
+var adapters = &sync.Map{}
+
+// NewLogger creates a golog.Logger that records its messages on a new Adapter, and writes them with t.Log, so they are
+// printed along with the output of the test that logged them, and only when it fails or runs in verbose mode. Fatal
+// and Panic messages are recorded without exiting or panicking. The adapter is used by AssertLogged and
+// AssertNotLogged until the test finishes.
+func NewLogger(t testing.TB, opts ...Option) (*golog.Logger, *Adapter) {
+	t.Helper()
+
+	adapter := New(opts...)
+	adapter.tb = t
+
+	adapters.Store(t, adapter)
+	t.Cleanup(func() {
+		adapter.finish()
+		adapters.Delete(t)
+	})
+
+	logger := golog.New(
+		golog.WithAdapter(adapter),
+		golog.WithExitFunc(func(int) {}),
+		golog.WithPanicFunc(func(string, error) {}),
+	)
+
+	return logger, adapter
+}
+
+// adapterOf returns the adapter created with NewLogger for the test.
+func adapterOf(t testing.TB) (*Adapter, bool) {
+	adapter, ok := adapters.Load(t)
+	if !ok {
+		return nil, false
+	}
+
+	return adapter.(*Adapter), true
+}
@@ -0,0 +1,224 @@
+// Package logtest provides an adapter that records the log messages in memory, with helpers to assert on them from
+// tests without parsing the output of a real adapter.
+package logtest
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danteay/golog"
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+// Entry is a recorded log message.
+type Entry struct {
+	Time  time.Time
+	Level levels.Level
+	// Message is the formatted message.
+	Message string
+	// Format and Args are the format string and the arguments the message was logged with.
+	Format string
+	Args   []any
+	Err    error
+	Fields map[string]any
+}
+
+// String returns the entry as a human-readable line, e.g. `[error] request failed error="timeout" status=500`.
+func (e Entry) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("[" + e.Level.String() + "] " + e.Message)
+
+	if e.Err != nil {
+		fmt.Fprintf(&sb, " error=%q", e.Err.Error())
+	}
+
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(&sb, " %s=%v", key, e.Fields[key])
+	}
+
+	return sb.String()
+}
+
+// Adapter is an adapter that records the log messages in memory. Fatal and Panic messages are recorded as the rest of
+// the messages, without exiting or panicking. It is safe for concurrent use.
+type Adapter struct {
+	mutex   *sync.Mutex
+	level   levels.Level
+	writer  io.Writer
+	tb      testing.TB
+	tbDone  bool
+	entries []Entry
+}
+
+var _ golog.EntryAdapter = (*Adapter)(nil)
+
+// New creates a recording adapter.
+func New(opts ...Option) *Adapter {
+	logOpts := options{
+		level:  levels.TraceLevel,
+		writer: io.Discard,
+	}
+
+	for _, opt := range opts {
+		opt(&logOpts)
+	}
+
+	return &Adapter{
+		mutex:  &sync.Mutex{},
+		level:  logOpts.level,
+		writer: logOpts.writer,
+	}
+}
+
+// Writer returns the writer for the adapter
+func (a *Adapter) Writer() io.Writer {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.writer
+}
+
+// SetWriter sets the writer for the adapter
+func (a *Adapter) SetWriter(w io.Writer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.writer = w
+}
+
+// Level returns the level for the adapter
+func (a *Adapter) Level() levels.Level {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.level
+}
+
+// SetLevel sets the level for the adapter
+func (a *Adapter) SetLevel(level levels.Level) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.level = level
+}
+
+// Log records a message with the given level, error, fields, and message
+func (a *Adapter) Log(level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	a.LogAt(time.Now(), level, err, logFields, msg, args...)
+}
+
+// LogAt records a message with the given timestamp, level, error, fields, and message
+func (a *Adapter) LogAt(t time.Time, level levels.Level, err error, logFields *fields.Fields, msg string, args ...any) {
+	if level <= levels.Disabled {
+		return
+	}
+
+	a.record(Entry{
+		Time:    t,
+		Level:   level,
+		Message: fmt.Sprintf(msg, args...),
+		Format:  msg,
+		Args:    args,
+		Err:     err,
+		Fields:  fieldsData(logFields),
+	})
+}
+
+// LogEntry records an entry written by a golog.Logger, keeping the format string and the arguments the message was
+// logged with.
+func (a *Adapter) LogEntry(entry *golog.Entry) {
+	if entry.Level <= levels.Disabled {
+		return
+	}
+
+	a.record(Entry{
+		Time:    entry.Time,
+		Level:   entry.Level,
+		Message: entry.Message,
+		Format:  entry.Format,
+		Args:    entry.Args,
+		Err:     entry.Err,
+		Fields:  fieldsData(entry.Fields),
+	})
+}
+
+// record stores the entry when its level is enabled, and writes it on the writer and with t.Log when the adapter was
+// created with NewLogger. Entries recorded once the test finished, e.g. by goroutines that outlive it, are not written
+// with t.Log, as it panics after the test completes.
+func (a *Adapter) record(entry Entry) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.level == levels.Disabled || entry.Level < a.level {
+		return
+	}
+
+	a.entries = append(a.entries, entry)
+	_, _ = io.WriteString(a.writer, entry.String()+"\n")
+
+	if a.tb != nil && !a.tbDone {
+		a.tb.Log(entry.String())
+	}
+}
+
+// finish stops writing the recorded entries with t.Log. It is called when the test of NewLogger finishes.
+func (a *Adapter) finish() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.tbDone = true
+}
+
+func fieldsData(logFields *fields.Fields) map[string]any {
+	if logFields == nil {
+		return map[string]any{}
+	}
+
+	return logFields.Data()
+}
+
+// Entries returns a copy of the recorded entries, in the order they were logged.
+func (a *Adapter) Entries() []Entry {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	entries := make([]Entry, len(a.entries))
+	copy(entries, a.entries)
+
+	return entries
+}
+
+// Filter returns the recorded entries for which match returns true, in the order they were logged.
+func (a *Adapter) Filter(match func(Entry) bool) []Entry {
+	var entries []Entry
+
+	for _, entry := range a.Entries() {
+		if match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// Reset removes the recorded entries.
+func (a *Adapter) Reset() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.entries = nil
+}
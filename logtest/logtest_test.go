@@ -0,0 +1,239 @@
+package logtest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog"
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+type fakeTB struct {
+	testing.TB
+	errors   []string
+	logs     []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Cleanup(cleanup func()) {
+	f.cleanups = append(f.cleanups, cleanup)
+}
+
+func (f *fakeTB) runCleanups() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Log(args ...any) {
+	f.logs = append(f.logs, fmt.Sprint(args...))
+}
+
+func TestAdapter_Log(t *testing.T) {
+	t.Run("should record the messages logged through a logger", func(t *testing.T) {
+		adapter := New()
+		logger := golog.New(golog.WithAdapter(adapter))
+		err := errors.New("test error")
+
+		logger.Field("user", "john").Err(err).Error("request %s", "failed")
+
+		entries := adapter.Entries()
+
+		assert.Len(t, entries, 1)
+		assert.Equal(t, levels.Error, entries[0].Level)
+		assert.Equal(t, "request failed", entries[0].Message)
+		assert.Equal(t, "request %s", entries[0].Format)
+		assert.Equal(t, []any{"failed"}, entries[0].Args)
+		assert.Equal(t, err, entries[0].Err)
+		assert.Equal(t, "john", entries[0].Fields["user"])
+		assert.False(t, entries[0].Time.IsZero())
+	})
+
+	t.Run("should keep the format and args", func(t *testing.T) {
+		adapter := New()
+		ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		adapter.LogAt(ts, levels.Info, nil, nil, "Test %s %d", "message", 1)
+
+		entries := adapter.Entries()
+
+		assert.Len(t, entries, 1)
+		assert.Equal(t, ts, entries[0].Time)
+		assert.Equal(t, "Test message 1", entries[0].Message)
+		assert.Equal(t, "Test %s %d", entries[0].Format)
+		assert.Equal(t, []any{"message", 1}, entries[0].Args)
+		assert.Equal(t, map[string]any{}, entries[0].Fields)
+	})
+
+	t.Run("should filter by level", func(t *testing.T) {
+		adapter := New(WithLevel(levels.Warn))
+
+		adapter.Log(levels.Info, nil, nil, "info message")
+		adapter.Log(levels.Warn, nil, nil, "warn message")
+
+		adapter.SetLevel(levels.Disabled)
+		adapter.Log(levels.Error, nil, nil, "error message")
+
+		assert.Len(t, adapter.Entries(), 1)
+		assert.Equal(t, levels.Disabled, adapter.Level())
+	})
+
+	t.Run("should record fatal and panic messages without exiting", func(t *testing.T) {
+		adapter := New()
+
+		assert.NotPanics(t, func() {
+			adapter.Log(levels.Fatal, nil, nil, "fatal message")
+			adapter.Log(levels.Panic, nil, nil, "panic message")
+		})
+
+		assert.Len(t, adapter.Entries(), 2)
+	})
+
+	t.Run("should write the messages on the writer", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := New(WithWriter(&logOutput))
+		logFields := fields.New().Set("b", 2).Set("a", "1")
+
+		adapter.Log(levels.Error, errors.New("test error"), logFields, "Test message")
+
+		assert.Equal(t, "[error] Test message error=\"test error\" a=1 b=2\n", logOutput.String())
+		assert.Equal(t, &logOutput, adapter.Writer())
+	})
+}
+
+func TestAdapter_FilterAndReset(t *testing.T) {
+	adapter := New()
+
+	adapter.Log(levels.Info, nil, nil, "first")
+	adapter.Log(levels.Error, nil, nil, "second")
+	adapter.Log(levels.Info, nil, nil, "third")
+
+	assert.Len(t, adapter.Filter(ByLevel(levels.Info)), 2)
+	assert.Len(t, adapter.Filter(ByMessage("second")), 1)
+
+	adapter.Reset()
+
+	assert.Empty(t, adapter.Entries())
+}
+
+func TestAdapter_AssertLogged(t *testing.T) {
+	adapter := New()
+	adapter.Log(levels.Error, nil, fields.New().Set("status", 500).Set("path", "/"), "request failed")
+
+	t.Run("should pass when the message was logged", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.True(t, adapter.AssertLogged(tb, levels.Error, "request failed"))
+		assert.True(t, adapter.AssertLogged(tb, levels.Error, "request failed", "status", 500))
+		assert.True(t, adapter.AssertNotLogged(tb, levels.Info, "request failed"))
+		assert.Empty(t, tb.errors)
+	})
+
+	t.Run("should fail when the message was not logged", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.False(t, adapter.AssertLogged(tb, levels.Error, "request failed", "status", 404))
+		assert.False(t, adapter.AssertLogged(tb, levels.Warn, "request failed"))
+		assert.False(t, adapter.AssertNotLogged(tb, levels.Error, "request failed"))
+
+		assert.Len(t, tb.errors, 3)
+		assert.Contains(t, tb.errors[0], "[error] request failed path=/ status=500")
+	})
+
+	t.Run("should fail with invalid key-value pairs", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.False(t, adapter.AssertLogged(tb, levels.Error, "request failed", "status"))
+		assert.False(t, adapter.AssertLogged(tb, levels.Error, "request failed", 1, 500))
+		assert.Len(t, tb.errors, 2)
+	})
+}
+
+func TestAssertLogged(t *testing.T) {
+	t.Run("should assert on the logger created for the test", func(t *testing.T) {
+		tb := &fakeTB{}
+		defer tb.runCleanups()
+
+		logger, _ := NewLogger(tb)
+
+		logger.Field("status", 500).Error("request %s", "failed")
+
+		assert.True(t, AssertLogged(tb, levels.Error, "request failed", "status", 500))
+		assert.True(t, AssertNotLogged(tb, levels.Warn, "request failed"))
+		assert.False(t, AssertLogged(tb, levels.Error, "request failed", "status", 404))
+		assert.False(t, AssertNotLogged(tb, levels.Error, "request failed"))
+		assert.Len(t, tb.errors, 2)
+	})
+
+	t.Run("should fail when no logger was created for the test", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		assert.False(t, AssertLogged(tb, levels.Error, "request failed"))
+		assert.False(t, AssertNotLogged(tb, levels.Error, "request failed"))
+		assert.Len(t, tb.errors, 2)
+	})
+
+	t.Run("should forget the logger when the test finishes", func(t *testing.T) {
+		tb := &fakeTB{}
+
+		logger, _ := NewLogger(tb)
+		logger.Info("Test message")
+
+		tb.runCleanups()
+
+		assert.False(t, AssertLogged(tb, levels.Info, "Test message"))
+		assert.Len(t, tb.errors, 1)
+	})
+}
+
+func TestNewLogger(t *testing.T) {
+	tb := &fakeTB{}
+	defer tb.runCleanups()
+
+	logger, adapter := NewLogger(tb)
+
+	logger.Field("user", "john").Info("Test message")
+
+	assert.Equal(t, []string{"[info] Test message user=john"}, tb.logs)
+	assert.True(t, adapter.AssertLogged(tb, levels.Info, "Test message", "user", "john"))
+}
+
+func TestNewLogger_AfterTest(t *testing.T) {
+	tb := &fakeTB{}
+
+	logger, adapter := NewLogger(tb)
+
+	logger.Info("Test message")
+	tb.runCleanups()
+	logger.Info("Late message")
+
+	assert.Equal(t, []string{"[info] Test message"}, tb.logs)
+	assert.Len(t, adapter.Entries(), 2)
+}
+
+func TestNewLogger_FatalAndPanic(t *testing.T) {
+	tb := &fakeTB{}
+	defer tb.runCleanups()
+
+	logger, adapter := NewLogger(tb)
+
+	assert.NotPanics(t, func() {
+		logger.Fatal("fatal message")
+		logger.Panic("panic message")
+	})
+
+	assert.Len(t, adapter.Entries(), 2)
+}
@@ -0,0 +1,31 @@
+package logtest
+
+import (
+	"io"
+
+	"github.com/danteay/golog/levels"
+)
+
+type options struct {
+	level  levels.Level
+	writer io.Writer
+}
+
+// Option defines the signature for the options.
+type Option func(*options)
+
+// WithLevel sets the minimum level of the recorded messages. Defaults to levels.TraceLevel, so every message is
+// recorded.
+func WithLevel(level levels.Level) Option {
+	return func(opts *options) {
+		opts.level = level
+	}
+}
+
+// WithWriter sets a writer where every recorded message is also written as a human-readable line. Defaults to
+// io.Discard.
+func WithWriter(writer io.Writer) Option {
+	return func(opts *options) {
+		opts.writer = writer
+	}
+}
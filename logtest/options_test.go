@@ -0,0 +1,25 @@
+package logtest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/levels"
+)
+
+func TestWithLevel(t *testing.T) {
+	opts := &options{}
+	WithLevel(levels.Warn)(opts)
+
+	assert.Equal(t, levels.Warn, opts.level)
+}
+
+func TestWithWriter(t *testing.T) {
+	opts := &options{}
+	writer := &bytes.Buffer{}
+	WithWriter(writer)(opts)
+
+	assert.Equal(t, writer, opts.writer)
+}
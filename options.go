@@ -1,7 +1,18 @@
 package golog
 
 type options struct {
-	adapter Adapter
+	adapter  Adapter
+	hooks    []Hook
+	sampler  Sampler
+	tailSize int
+
+	exitFunc      func(code int)
+	exitCode      int
+	shutdownHooks []func()
+	panicFunc     PanicFunc
+
+	caller     bool
+	callerSkip int
 }
 
 type Option func(*options)
@@ -16,3 +27,90 @@ func WithAdapter(adapter Adapter) Option {
 		opts.adapter = adapter
 	}
 }
+
+// WithHooks adds hooks that run on every log entry before it reaches the adapter. Hooks run in the order they are
+// registered, across all the WithHooks options used.
+func WithHooks(hooks ...Hook) Option {
+	return func(opts *options) {
+		for _, hook := range hooks {
+			if hook != nil {
+				opts.hooks = append(opts.hooks, hook)
+			}
+		}
+	}
+}
+
+// WithSampler sets the sampler used to discard repeated log entries. Entries with Error level or above are never
+// sampled.
+func WithSampler(sampler Sampler) Option {
+	return func(opts *options) {
+		opts.sampler = sampler
+	}
+}
+
+// WithTailBuffer enables tail buffering for executions created with NewExecution. Debug and Trace messages of an
+// execution are kept in memory, up to size messages, instead of being written. When a message with Error level or
+// above is logged for the execution, the buffered messages are written first, in order and with their original
+// timestamps. If the execution finishes without errors, the buffered messages are discarded when the execution
+// context is done, when its context fields are flushed, or when they are not used within the ttl set with
+// SetContextFieldsTTL. Up to 10000 executions are buffered at the same time, discarding the buffer of the execution
+// that logged least recently to make room for a new one.
+//
+// The adapter level should allow Debug or Trace messages, otherwise they are discarded by the logger before being
+// buffered.
+func WithTailBuffer(size int) Option {
+	return func(opts *options) {
+		opts.tailSize = size
+	}
+}
+
+// WithExitFunc sets the function called to exit the program after writing Fatal messages. Defaults to os.Exit.
+func WithExitFunc(exitFunc func(code int)) Option {
+	return func(opts *options) {
+		opts.exitFunc = exitFunc
+	}
+}
+
+// WithExitCode sets the exit code used after writing Fatal messages. Defaults to DefaultExitCode.
+func WithExitCode(code int) Option {
+	return func(opts *options) {
+		opts.exitCode = code
+	}
+}
+
+// WithShutdownHooks adds functions that run after writing a Fatal message and before exiting the program, e.g. to
+// close connections or flush metrics. Hooks run in the order they are registered, across all the WithShutdownHooks
+// options used.
+func WithShutdownHooks(hooks ...func()) Option {
+	return func(opts *options) {
+		for _, hook := range hooks {
+			if hook != nil {
+				opts.shutdownHooks = append(opts.shutdownHooks, hook)
+			}
+		}
+	}
+}
+
+// WithPanicFunc sets the function called after writing Panic messages. Defaults to DefaultPanicFunc, that panics with
+// the logger error or with the message when no error is set.
+func WithPanicFunc(panicFunc PanicFunc) Option {
+	return func(opts *options) {
+		opts.panicFunc = panicFunc
+	}
+}
+
+// WithCaller adds the source field, with the function, file and line of the code that logged the message, to every
+// message. Functions that wrap the logger can skip their own frames with WithCallerSkip or Logger.CallerSkip.
+func WithCaller() Option {
+	return func(opts *options) {
+		opts.caller = true
+	}
+}
+
+// WithCallerSkip sets the number of additional stack frames skipped when capturing the caller, e.g. 1 when every log
+// call goes through a helper function.
+func WithCallerSkip(skip int) Option {
+	return func(opts *options) {
+		opts.callerSkip = skip
+	}
+}
@@ -2,6 +2,7 @@ package golog
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -16,3 +17,111 @@ func TestWithAdapter(t *testing.T) {
 
 	assert.Same(t, adapter, opts.adapter)
 }
+
+func TestWithHooks(t *testing.T) {
+	opts := &options{}
+
+	var calls []int
+
+	hook1 := func(_ *Entry) bool {
+		calls = append(calls, 1)
+		return true
+	}
+
+	hook2 := func(_ *Entry) bool {
+		calls = append(calls, 2)
+		return true
+	}
+
+	WithHooks(hook1, nil)(opts)
+	WithHooks(hook2)(opts)
+
+	assert.Len(t, opts.hooks, 2)
+
+	for _, hook := range opts.hooks {
+		hook(&Entry{})
+	}
+
+	assert.Equal(t, []int{1, 2}, calls)
+}
+
+func TestWithSampler(t *testing.T) {
+	opts := &options{}
+	sampler := NewFirstNSampler(1, 0, time.Second)
+
+	WithSampler(sampler)(opts)
+
+	assert.Same(t, sampler, opts.sampler)
+}
+
+func TestWithTailBuffer(t *testing.T) {
+	opts := &options{}
+
+	WithTailBuffer(100)(opts)
+
+	assert.Equal(t, 100, opts.tailSize)
+}
+
+func TestWithExitFunc(t *testing.T) {
+	opts := &options{}
+	code := 0
+
+	WithExitFunc(func(c int) { code = c })(opts)
+
+	opts.exitFunc(2)
+
+	assert.Equal(t, 2, code)
+}
+
+func TestWithExitCode(t *testing.T) {
+	opts := &options{}
+
+	WithExitCode(3)(opts)
+
+	assert.Equal(t, 3, opts.exitCode)
+}
+
+func TestWithShutdownHooks(t *testing.T) {
+	opts := &options{}
+
+	var calls []int
+
+	WithShutdownHooks(func() { calls = append(calls, 1) }, nil)(opts)
+	WithShutdownHooks(func() { calls = append(calls, 2) })(opts)
+
+	assert.Len(t, opts.shutdownHooks, 2)
+
+	for _, hook := range opts.shutdownHooks {
+		hook()
+	}
+
+	assert.Equal(t, []int{1, 2}, calls)
+}
+
+func TestWithPanicFunc(t *testing.T) {
+	opts := &options{}
+
+	var messages []string
+
+	WithPanicFunc(func(msg string, _ error) { messages = append(messages, msg) })(opts)
+
+	opts.panicFunc("Test message", nil)
+
+	assert.Equal(t, []string{"Test message"}, messages)
+}
+
+func TestWithCaller(t *testing.T) {
+	opts := &options{}
+
+	WithCaller()(opts)
+
+	assert.True(t, opts.caller)
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	opts := &options{}
+
+	WithCallerSkip(2)(opts)
+
+	assert.Equal(t, 2, opts.callerSkip)
+}
@@ -0,0 +1,73 @@
+package redact
+
+import (
+	"regexp"
+)
+
+type options struct {
+	keys     []string
+	globs    []string
+	regexps  []*regexp.Regexp
+	patterns []Pattern
+	mode     Mode
+	mask     string
+	salt     string
+}
+
+type Option func(*options)
+
+// WithKeys redacts the fields with any of the provided names, also when the name is the last segment of a dot separated
+// key, e.g. "password" redacts the "user.password" and "error.password" fields as well.
+func WithKeys(keys ...string) Option {
+	return func(opts *options) {
+		opts.keys = append(opts.keys, keys...)
+	}
+}
+
+// WithKeyGlobs redacts the fields whose name matches any of the provided glob patterns, using the path.Match syntax,
+// e.g. "*_token" or "pass*".
+func WithKeyGlobs(patterns ...string) Option {
+	return func(opts *options) {
+		opts.globs = append(opts.globs, patterns...)
+	}
+}
+
+// WithKeyRegexps redacts the fields whose name matches any of the provided regular expressions.
+func WithKeyRegexps(patterns ...*regexp.Regexp) Option {
+	return func(opts *options) {
+		for _, pattern := range patterns {
+			if pattern != nil {
+				opts.regexps = append(opts.regexps, pattern)
+			}
+		}
+	}
+}
+
+// WithPatterns redacts the parts of the message and of the string field values that match any of the provided
+// patterns, e.g. Email or CreditCard.
+func WithPatterns(patterns ...Pattern) Option {
+	return func(opts *options) {
+		opts.patterns = append(opts.patterns, patterns...)
+	}
+}
+
+// WithMode sets how the sensitive values are redacted. Defaults to Mask.
+func WithMode(mode Mode) Option {
+	return func(opts *options) {
+		opts.mode = mode
+	}
+}
+
+// WithMask sets the text used to replace the sensitive values on Mask mode. Defaults to "[REDACTED]".
+func WithMask(mask string) Option {
+	return func(opts *options) {
+		opts.mask = mask
+	}
+}
+
+// WithSalt sets the salt prepended to the sensitive values before hashing them on Hash mode.
+func WithSalt(salt string) Option {
+	return func(opts *options) {
+		opts.salt = salt
+	}
+}
@@ -0,0 +1,101 @@
+package redact
+
+import (
+	"regexp"
+)
+
+// Pattern identifies sensitive values inside the message and the string field values.
+type Pattern struct {
+	re       *regexp.Regexp
+	validate func(match string) bool
+}
+
+var (
+	// Email matches email addresses.
+	Email = NewPattern(regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`), nil)
+
+	// CreditCard matches credit card numbers, optionally separated by spaces or dashes, that pass the Luhn check.
+	CreditCard = NewPattern(regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`), luhn)
+
+	// JWT matches JSON Web Tokens.
+	JWT = NewPattern(regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`), nil)
+
+	// BearerToken matches the token of bearer authorization values, keeping the "Bearer" prefix.
+	BearerToken = NewPattern(regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`), nil)
+)
+
+// NewPattern creates a pattern that matches the provided regular expression. If the expression has a capturing
+// group, only the text of the first group is redacted. If validate is not nil, only the matches it accepts are
+// redacted.
+func NewPattern(re *regexp.Regexp, validate func(match string) bool) Pattern {
+	return Pattern{re: re, validate: validate}
+}
+
+// replace returns s with the matches of the pattern replaced by the result of repl.
+func (p Pattern) replace(s string, repl func(match string) string) string {
+	if p.re == nil {
+		return s
+	}
+
+	indexes := p.re.FindAllStringSubmatchIndex(s, -1)
+	if len(indexes) == 0 {
+		return s
+	}
+
+	var (
+		res  []byte
+		last int
+	)
+
+	for _, index := range indexes {
+		start, end := index[0], index[1]
+		if len(index) > 2 && index[2] >= 0 {
+			start, end = index[2], index[3]
+		}
+
+		match := s[start:end]
+		if p.validate != nil && !p.validate(match) {
+			continue
+		}
+
+		res = append(res, s[last:start]...)
+		res = append(res, repl(match)...)
+		last = end
+	}
+
+	if res == nil {
+		return s
+	}
+
+	return string(append(res, s[last:]...))
+}
+
+// luhn reports whether the digits of the value pass the Luhn checksum, ignoring any separator.
+func luhn(value string) bool {
+	var (
+		sum    int
+		digits int
+		double bool
+	)
+
+	for i := len(value) - 1; i >= 0; i-- {
+		c := value[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		digits++
+		double = !double
+	}
+
+	return digits >= 13 && sum%10 == 0
+}
@@ -0,0 +1,485 @@
+// Package redact removes sensitive values from the log entries before they reach the adapter.
+//
+// A Redactor is registered as a logger hook, so it applies the same way whichever adapter is configured:
+//
+//	redactor := redact.New(
+//		redact.WithKeys("password"),
+//		redact.WithKeyGlobs("*_token"),
+//		redact.WithPatterns(redact.Email, redact.CreditCard),
+//	)
+//
+//	logger := golog.New(golog.WithHooks(redactor.Hook))
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/danteay/golog"
+	"github.com/danteay/golog/fields"
+)
+
+// Mode defines how the sensitive values are redacted.
+type Mode int
+
+const (
+	// Mask replaces the sensitive values with the configured mask.
+	Mask Mode = iota
+
+	// Remove deletes the sensitive fields, and removes the sensitive parts of the message and string values.
+	Remove
+
+	// Hash replaces the sensitive values with the hex encoded SHA-256 of the salted value, so equal values can still be
+	// correlated across log entries.
+	Hash
+)
+
+// TagName is the struct tag used to mark the fields of logged structs that should be redacted, e.g. `log:"redact"`.
+var TagName = "log"
+
+const (
+	tagValue = "redact"
+	maxDepth = 32
+)
+
+// Redactor redacts sensitive values by field name, by value pattern and by struct tag.
+type Redactor struct {
+	keys     map[string]struct{}
+	globs    []string
+	opts     options
+	tagCache *sync.Map
+}
+
+// New creates a new Redactor with the provided options.
+func New(opts ...Option) *Redactor {
+	redactOpts := options{
+		mode: Mask,
+		mask: "[REDACTED]",
+	}
+
+	for _, opt := range opts {
+		opt(&redactOpts)
+	}
+
+	r := &Redactor{
+		keys:     make(map[string]struct{}, len(redactOpts.keys)),
+		opts:     redactOpts,
+		tagCache: &sync.Map{},
+	}
+
+	for _, key := range redactOpts.keys {
+		r.keys[key] = struct{}{}
+	}
+
+	for _, glob := range redactOpts.globs {
+		if _, err := path.Match(glob, ""); err == nil {
+			r.globs = append(r.globs, glob)
+		}
+	}
+
+	return r
+}
+
+// Hook redacts the message, the error and the fields of the entry. It can be registered with golog.WithHooks,
+// preferably after any hook that adds fields so they are redacted as well.
+func (r *Redactor) Hook(entry *golog.Entry) bool {
+	entry.Message = r.Message(entry.Message)
+	entry.Err = r.Error(entry.Err)
+	r.Fields(entry.Fields)
+
+	return true
+}
+
+// Error returns err with the parts of its message that match the configured patterns redacted. The returned error
+// still unwraps to err, so errors.Is and errors.As keep working, and err is returned as is when nothing was redacted.
+func (r *Redactor) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+
+	redacted := r.redactString(msg)
+	if redacted == msg {
+		return err
+	}
+
+	return &redactedError{err: err, msg: redacted}
+}
+
+// Message returns msg with the parts that match the configured patterns redacted.
+func (r *Redactor) Message(msg string) string {
+	return r.redactString(msg)
+}
+
+// Fields redacts the provided fields in place. Fields whose name matches the configured keys are redacted
+// completely; the rest of the values are inspected for nested maps, slices, tagged structs and matching patterns.
+// Only the fields whose value changed are replaced, keeping the kind of their value.
+func (r *Redactor) Fields(logFields *fields.Fields) {
+	if logFields == nil {
+		return
+	}
+
+	var (
+		removed  []string
+		redacted []fields.Field
+	)
+
+	logFields.RangeFields(func(field fields.Field) bool {
+		if r.matchKey(field.Key) {
+			if r.opts.mode == Remove {
+				removed = append(removed, field.Key)
+			} else {
+				redacted = append(redacted, fields.String(field.Key, r.replacement(field.Value())))
+			}
+
+			return true
+		}
+
+		if res, changed := r.redactField(field); changed {
+			redacted = append(redacted, res)
+		}
+
+		return true
+	})
+
+	for _, key := range removed {
+		logFields.Delete(key)
+	}
+
+	logFields.SetFields(redacted...)
+}
+
+// redactField returns the field with its value redacted, and whether the value changed. Only string, object and
+// untyped values are inspected, as the rest of the kinds can't hold sensitive text.
+func (r *Redactor) redactField(field fields.Field) (fields.Field, bool) {
+	switch field.Kind() {
+	case fields.KindString:
+		value := field.StringValue()
+
+		res := r.redactString(value)
+		if res == value {
+			return field, false
+		}
+
+		return fields.String(field.Key, res), true
+	case fields.KindObject, fields.KindAny:
+		value := field.Value()
+
+		res := r.redactValue(value, 0)
+		if reflect.DeepEqual(res, value) {
+			return field, false
+		}
+
+		if field.Kind() == fields.KindObject {
+			return fields.Object(field.Key, res), true
+		}
+
+		return fields.Any(field.Key, res), true
+	default:
+		return field, false
+	}
+}
+
+// matchKey reports whether the key is sensitive. Keys are also matched by their last dot separated segment, so
+// "password" matches the "user.password" and "error.password" keys as well.
+func (r *Redactor) matchKey(key string) bool {
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		if _, ok := r.keys[key[i+1:]]; ok {
+			return true
+		}
+	}
+
+	for _, glob := range r.globs {
+		if ok, _ := path.Match(glob, key); ok {
+			return true
+		}
+	}
+
+	for _, re := range r.opts.regexps {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replacement returns the redacted form of a sensitive value.
+func (r *Redactor) replacement(value any) string {
+	switch r.opts.mode {
+	case Remove:
+		return ""
+	case Hash:
+		s, ok := value.(string)
+		if !ok {
+			s = fmt.Sprint(value)
+		}
+
+		sum := sha256.Sum256([]byte(r.opts.salt + s))
+
+		return hex.EncodeToString(sum[:])
+	default:
+		return r.opts.mask
+	}
+}
+
+func (r *Redactor) redactString(s string) string {
+	for _, pattern := range r.opts.patterns {
+		s = pattern.replace(s, func(match string) string {
+			return r.replacement(match)
+		})
+	}
+
+	return s
+}
+
+func (r *Redactor) redactValue(value any, depth int) any {
+	if depth > maxDepth {
+		return value
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return r.redactString(v)
+	case map[string]any:
+		res := make(map[string]any, len(v))
+
+		for key, val := range v {
+			if r.matchKey(key) {
+				if r.opts.mode != Remove {
+					res[key] = r.replacement(val)
+				}
+
+				continue
+			}
+
+			res[key] = r.redactValue(val, depth+1)
+		}
+
+		return res
+	case []any:
+		res := make([]any, len(v))
+
+		for i, val := range v {
+			res[i] = r.redactValue(val, depth+1)
+		}
+
+		return res
+	case []string:
+		res := make([]string, len(v))
+
+		for i, val := range v {
+			res[i] = r.redactString(val)
+		}
+
+		return res
+	case golog.ErrorInfo:
+		return r.redactErrorInfo(v, depth)
+	}
+
+	rv := reflect.ValueOf(value)
+	if r.taggedType(rv.Type()) {
+		return r.redactTagged(rv, depth).Interface()
+	}
+
+	return value
+}
+
+// redactErrorInfo returns a copy of the error info with the messages of the error and its causes redacted.
+func (r *Redactor) redactErrorInfo(info golog.ErrorInfo, depth int) golog.ErrorInfo {
+	info.Message = r.redactString(info.Message)
+
+	if len(info.Causes) == 0 || depth > maxDepth {
+		return info
+	}
+
+	causes := make([]golog.ErrorInfo, len(info.Causes))
+
+	for i, cause := range info.Causes {
+		causes[i] = r.redactErrorInfo(cause, depth+1)
+	}
+
+	info.Causes = causes
+
+	return info
+}
+
+// redactTagged returns a copy of the value with the tagged fields redacted. The value must be a struct with tagged
+// fields, or a pointer, slice or array of them, as reported by taggedType.
+func (r *Redactor) redactTagged(v reflect.Value, depth int) reflect.Value {
+	if depth > maxDepth {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return r.redactStruct(v, depth)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		res := reflect.New(v.Type().Elem())
+		res.Elem().Set(r.redactTagged(v.Elem(), depth+1))
+
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(r.redactTagged(v.Index(i), depth+1))
+		}
+
+		return res
+	case reflect.Array:
+		res := reflect.New(v.Type()).Elem()
+
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(r.redactTagged(v.Index(i), depth+1))
+		}
+
+		return res
+	}
+
+	return v
+}
+
+// redactStruct returns a copy of the struct with the tagged fields redacted, so the logged value is not modified.
+func (r *Redactor) redactStruct(v reflect.Value, depth int) reflect.Value {
+	res := reflect.New(v.Type()).Elem()
+	res.Set(v)
+
+	if depth > maxDepth {
+		return res
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := res.Field(i)
+
+		if isTagged(field) {
+			if fv.Kind() == reflect.String && r.opts.mode != Remove {
+				fv.SetString(r.replacement(fv.String()))
+				continue
+			}
+
+			fv.Set(reflect.Zero(field.Type))
+
+			continue
+		}
+
+		if r.taggedType(field.Type) {
+			fv.Set(r.redactTagged(fv, depth+1))
+		}
+	}
+
+	return res
+}
+
+// taggedType reports whether the values of the type can hold tagged fields, which is true for structs with tagged
+// fields and for pointers, slices and arrays of them.
+func (r *Redactor) taggedType(t reflect.Type) bool {
+	t = baseType(t)
+
+	return t.Kind() == reflect.Struct && r.hasTags(t)
+}
+
+// hasTags reports whether the struct type has tagged fields, directly or on nested structs, caching the result.
+func (r *Redactor) hasTags(t reflect.Type) bool {
+	if cached, ok := r.tagCache.Load(t); ok {
+		return cached.(bool)
+	}
+
+	res := r.findTags(t, map[reflect.Type]struct{}{})
+	r.tagCache.Store(t, res)
+
+	return res
+}
+
+// findTags looks for tagged fields on the struct type, skipping the types already visited so recursive types do not
+// loop forever.
+func (r *Redactor) findTags(t reflect.Type, visited map[reflect.Type]struct{}) bool {
+	if cached, ok := r.tagCache.Load(t); ok {
+		return cached.(bool)
+	}
+
+	if _, ok := visited[t]; ok {
+		return false
+	}
+
+	visited[t] = struct{}{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		ft := baseType(field.Type)
+
+		if isTagged(field) || (ft.Kind() == reflect.Struct && r.findTags(ft, visited)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// baseType returns the type of the values held by the type when it is a pointer, slice or array, following nested
+// ones up to the maximum depth.
+func baseType(t reflect.Type) reflect.Type {
+	for i := 0; i < maxDepth; i++ {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+
+	return t
+}
+
+func isTagged(field reflect.StructField) bool {
+	for _, opt := range strings.Split(field.Tag.Get(TagName), ",") {
+		if opt == tagValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactedError is the error of a redacted entry, which has the redacted message of the original error.
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,255 @@
+package golog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/danteay/golog/levels"
+)
+
+// Sampler decides which log entries are written. Entries are identified by their level and message template, before
+// formatting the arguments. Entries with Error level or above are never sampled.
+type Sampler interface {
+	// Sample reports whether the entry should be written and, when it is, the number of entries with the same level
+	// and message template that were discarded since the last written one.
+	Sample(level levels.Level, msg string) (keep bool, dropped uint64)
+
+	// Dropped returns the number of entries with the same level and message template that were discarded since the
+	// last written one, and resets it, so they are not reported again by Sample.
+	Dropped(level levels.Level, msg string) uint64
+}
+
+// samplingSummaryDelay is the time the Logger waits after discarding an entry before reporting the discarded entries
+// that were not followed by a written one.
+const samplingSummaryDelay = time.Second
+
+type samplerKey struct {
+	level levels.Level
+	msg   string
+}
+
+type firstNCounter struct {
+	windowStart time.Time
+	count       uint64
+	dropped     uint64
+}
+
+type firstNSampler struct {
+	mutex      *sync.Mutex
+	first      uint64
+	thereafter uint64
+	interval   time.Duration
+	lastSweep  time.Time
+	counters   map[samplerKey]*firstNCounter
+	now        func() time.Time
+}
+
+// NewFirstNSampler creates a sampler that writes the first entries of each level and message template within every
+// interval, and then one of every thereafter entries. If thereafter is zero, all the entries after the first ones
+// are discarded until the next interval.
+func NewFirstNSampler(first, thereafter uint64, interval time.Duration) Sampler {
+	return &firstNSampler{
+		mutex:      &sync.Mutex{},
+		first:      first,
+		thereafter: thereafter,
+		interval:   interval,
+		counters:   make(map[samplerKey]*firstNCounter),
+		now:        time.Now,
+	}
+}
+
+// Sample implements the Sampler interface.
+func (s *firstNSampler) Sample(level levels.Level, msg string) (keep bool, dropped uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	key := samplerKey{level: level, msg: msg}
+
+	counter, exists := s.counters[key]
+	if !exists || now.Sub(counter.windowStart) >= s.interval {
+		if counter == nil {
+			counter = &firstNCounter{}
+			s.counters[key] = counter
+		}
+
+		counter.windowStart = now
+		counter.count = 0
+	}
+
+	counter.count++
+
+	if counter.count <= s.first || (s.thereafter > 0 && (counter.count-s.first)%s.thereafter == 0) {
+		dropped, counter.dropped = counter.dropped, 0
+		return true, dropped
+	}
+
+	counter.dropped++
+
+	return false, 0
+}
+
+// Dropped implements the Sampler interface.
+func (s *firstNSampler) Dropped(level levels.Level, msg string) uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	counter, exists := s.counters[samplerKey{level: level, msg: msg}]
+	if !exists {
+		return 0
+	}
+
+	dropped := counter.dropped
+	counter.dropped = 0
+
+	return dropped
+}
+
+// sweep removes the counters of the keys that were not used in the last interval and have no pending dropped
+// entries to report.
+func (s *firstNSampler) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.interval {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, counter := range s.counters {
+		if counter.dropped == 0 && now.Sub(counter.windowStart) >= s.interval {
+			delete(s.counters, key)
+		}
+	}
+}
+
+type bucket struct {
+	tokens  float64
+	last    time.Time
+	dropped uint64
+}
+
+type tokenBucketSampler struct {
+	mutex     *sync.Mutex
+	rate      float64
+	burst     float64
+	lastSweep time.Time
+	buckets   map[samplerKey]*bucket
+	now       func() time.Time
+}
+
+// NewTokenBucketSampler creates a sampler that writes up to rate entries per second of each level and message
+// template, allowing bursts of up to burst entries.
+func NewTokenBucketSampler(rate float64, burst int) Sampler {
+	return &tokenBucketSampler{
+		mutex:   &sync.Mutex{},
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[samplerKey]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Sample implements the Sampler interface.
+func (s *tokenBucketSampler) Sample(level levels.Level, msg string) (keep bool, dropped uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	key := samplerKey{level: level, msg: msg}
+
+	b, exists := s.buckets[key]
+	if !exists {
+		b = &bucket{tokens: s.burst, last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * s.rate
+	if b.tokens > s.burst {
+		b.tokens = s.burst
+	}
+
+	b.last = now
+
+	if b.tokens < 1 {
+		b.dropped++
+		return false, 0
+	}
+
+	b.tokens--
+	dropped, b.dropped = b.dropped, 0
+
+	return true, dropped
+}
+
+// Dropped implements the Sampler interface.
+func (s *tokenBucketSampler) Dropped(level levels.Level, msg string) uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	b, exists := s.buckets[samplerKey{level: level, msg: msg}]
+	if !exists {
+		return 0
+	}
+
+	dropped := b.dropped
+	b.dropped = 0
+
+	return dropped
+}
+
+// sweep removes the buckets that are full again and have no pending dropped entries to report, as they are
+// equivalent to a new bucket.
+func (s *tokenBucketSampler) sweep(now time.Time) {
+	if s.rate <= 0 || now.Sub(s.lastSweep).Seconds()*s.rate < s.burst {
+		return
+	}
+
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.dropped == 0 && b.tokens+now.Sub(b.last).Seconds()*s.rate >= s.burst {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// samplingSummaries keeps track of the summaries scheduled for the discarded entries of each level and message
+// template, so only one is pending at a time.
+type samplingSummaries struct {
+	mutex   *sync.Mutex
+	delay   time.Duration
+	pending map[samplerKey]struct{}
+}
+
+func newSamplingSummaries() *samplingSummaries {
+	return &samplingSummaries{
+		mutex:   &sync.Mutex{},
+		delay:   samplingSummaryDelay,
+		pending: make(map[samplerKey]struct{}),
+	}
+}
+
+// schedule reports whether a summary should be scheduled for the key, which is true when there is none pending.
+func (s *samplingSummaries) schedule(key samplerKey) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.pending[key]; exists {
+		return false
+	}
+
+	s.pending[key] = struct{}{}
+
+	return true
+}
+
+// done removes the pending summary of the key.
+func (s *samplingSummaries) done(key samplerKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.pending, key)
+}
@@ -0,0 +1,272 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/levels"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type lockedBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) Lines() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return strings.Split(strings.TrimSpace(b.buffer.String()), "\n")
+}
+
+func TestFirstNSampler(t *testing.T) {
+	t.Run("should keep first entries and then one every thereafter", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		sampler := NewFirstNSampler(2, 3, time.Second).(*firstNSampler)
+		sampler.now = clock.Now
+
+		var kept []int
+		var dropped []uint64
+
+		for i := 1; i <= 8; i++ {
+			if keep, d := sampler.Sample(levels.Info, "message"); keep {
+				kept = append(kept, i)
+				dropped = append(dropped, d)
+			}
+		}
+
+		assert.Equal(t, []int{1, 2, 5, 8}, kept)
+		assert.Equal(t, []uint64{0, 0, 2, 2}, dropped)
+	})
+
+	t.Run("should group by level and message", func(t *testing.T) {
+		sampler := NewFirstNSampler(1, 0, time.Second)
+
+		keep1, _ := sampler.Sample(levels.Info, "message")
+		keep2, _ := sampler.Sample(levels.Info, "message")
+		keep3, _ := sampler.Sample(levels.Warn, "message")
+		keep4, _ := sampler.Sample(levels.Info, "other message")
+
+		assert.True(t, keep1)
+		assert.False(t, keep2)
+		assert.True(t, keep3)
+		assert.True(t, keep4)
+	})
+
+	t.Run("should reset counters every interval", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		sampler := NewFirstNSampler(1, 0, time.Second).(*firstNSampler)
+		sampler.now = clock.Now
+
+		keep1, _ := sampler.Sample(levels.Info, "message")
+		keep2, _ := sampler.Sample(levels.Info, "message")
+
+		clock.Add(time.Second)
+
+		keep3, dropped := sampler.Sample(levels.Info, "message")
+
+		assert.True(t, keep1)
+		assert.False(t, keep2)
+		assert.True(t, keep3)
+		assert.Equal(t, uint64(1), dropped)
+	})
+
+	t.Run("should remove unused counters", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		sampler := NewFirstNSampler(1, 0, time.Second).(*firstNSampler)
+		sampler.now = clock.Now
+
+		sampler.Sample(levels.Info, "message")
+
+		clock.Add(time.Second)
+
+		sampler.Sample(levels.Info, "other message")
+
+		assert.Len(t, sampler.counters, 1)
+	})
+
+	t.Run("should return and reset dropped entries", func(t *testing.T) {
+		sampler := NewFirstNSampler(1, 0, time.Second)
+
+		sampler.Sample(levels.Info, "message")
+		sampler.Sample(levels.Info, "message")
+		sampler.Sample(levels.Info, "message")
+
+		assert.Equal(t, uint64(2), sampler.Dropped(levels.Info, "message"))
+		assert.Equal(t, uint64(0), sampler.Dropped(levels.Info, "message"))
+		assert.Equal(t, uint64(0), sampler.Dropped(levels.Info, "other message"))
+	})
+}
+
+func TestTokenBucketSampler(t *testing.T) {
+	t.Run("should keep entries up to burst and refill by rate", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		sampler := NewTokenBucketSampler(2, 2).(*tokenBucketSampler)
+		sampler.now = clock.Now
+
+		keep1, _ := sampler.Sample(levels.Info, "message")
+		keep2, _ := sampler.Sample(levels.Info, "message")
+		keep3, _ := sampler.Sample(levels.Info, "message")
+		keep4, _ := sampler.Sample(levels.Info, "message")
+
+		assert.True(t, keep1)
+		assert.True(t, keep2)
+		assert.False(t, keep3)
+		assert.False(t, keep4)
+
+		clock.Add(500 * time.Millisecond)
+
+		keep5, dropped := sampler.Sample(levels.Info, "message")
+		keep6, _ := sampler.Sample(levels.Info, "message")
+
+		assert.True(t, keep5)
+		assert.Equal(t, uint64(2), dropped)
+		assert.False(t, keep6)
+	})
+
+	t.Run("should remove full buckets", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		sampler := NewTokenBucketSampler(1, 1).(*tokenBucketSampler)
+		sampler.now = clock.Now
+
+		sampler.Sample(levels.Info, "message")
+
+		clock.Add(time.Second)
+
+		sampler.Sample(levels.Info, "other message")
+
+		assert.Len(t, sampler.buckets, 1)
+	})
+
+	t.Run("should return and reset dropped entries", func(t *testing.T) {
+		sampler := NewTokenBucketSampler(1, 1)
+
+		sampler.Sample(levels.Info, "message")
+		sampler.Sample(levels.Info, "message")
+
+		assert.Equal(t, uint64(1), sampler.Dropped(levels.Info, "message"))
+		assert.Equal(t, uint64(0), sampler.Dropped(levels.Info, "message"))
+		assert.Equal(t, uint64(0), sampler.Dropped(levels.Info, "other message"))
+	})
+}
+
+func TestLoggerSampling(t *testing.T) {
+	t.Run("should sample entries and report dropped ones", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		clock := &fakeClock{now: time.Now()}
+
+		sampler := NewFirstNSampler(1, 0, time.Second).(*firstNSampler)
+		sampler.now = clock.Now
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithSampler(sampler))
+
+		for i := 0; i < 3; i++ {
+			logger.Info("request %d", i)
+		}
+
+		clock.Add(time.Second)
+
+		logger.Info("request %d", 3)
+
+		lines := strings.Split(strings.TrimSpace(logOutput.String()), "\n")
+		assert.Len(t, lines, 3)
+
+		msgs := make([]map[string]any, 0, len(lines))
+
+		for _, line := range lines {
+			res := map[string]any{}
+
+			if errUnmarshal := json.Unmarshal([]byte(line), &res); errUnmarshal != nil {
+				t.Fatal(errUnmarshal)
+			}
+
+			msgs = append(msgs, res)
+		}
+
+		assert.Equal(t, "request 0", msgs[0]["message"])
+		assert.Equal(t, "sampled out 2 entries", msgs[1]["message"])
+		assert.Equal(t, "request %d", msgs[1]["sampled_message"])
+		assert.Equal(t, float64(2), msgs[1]["sampled_count"])
+		assert.Equal(t, "request 3", msgs[2]["message"])
+	})
+
+	t.Run("should report dropped entries when no other entry is written", func(t *testing.T) {
+		var logOutput lockedBuffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithSampler(NewFirstNSampler(1, 0, time.Hour)))
+		logger.summaries.delay = 10 * time.Millisecond
+
+		ctx := NewExecution(context.Background(), "some-exec-id")
+		defer FlushAllContextFields()
+
+		logger = logger.SetContext(ctx).SetContextFields(map[string]any{"request": "some-request"})
+
+		for i := 0; i < 3; i++ {
+			logger.Info("request %d", i)
+		}
+
+		assert.Eventually(t, func() bool {
+			return len(logOutput.Lines()) == 2
+		}, time.Second, time.Millisecond)
+
+		res := map[string]any{}
+		if errUnmarshal := json.Unmarshal([]byte(logOutput.Lines()[1]), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		assert.Equal(t, "sampled out 2 entries", res["message"])
+		assert.Equal(t, "request %d", res["sampled_message"])
+		assert.Equal(t, float64(2), res["sampled_count"])
+		assert.Equal(t, "some-request", res["request"])
+		assert.Equal(t, "some-exec-id", res[ExecutionIDField])
+	})
+
+	t.Run("should never sample errors", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		adapter := slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))
+		logger := New(WithAdapter(adapter), WithSampler(NewFirstNSampler(0, 0, time.Hour)))
+
+		logger.Warn("warning")
+		logger.Error("error")
+		logger.Error("error")
+
+		lines := strings.Split(strings.TrimSpace(logOutput.String()), "\n")
+		assert.Len(t, lines, 2)
+	})
+}
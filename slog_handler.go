@@ -0,0 +1,182 @@
+package golog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/internal/contextfields"
+	"github.com/danteay/golog/levels"
+)
+
+// SlogHandler is a slog.Handler that writes the records through a Logger, so libraries that log with a *slog.Logger
+// share the same adapter, hooks, sampling and context fields.
+//
+// Record attributes are added as fields, using dot separated keys for groups (e.g. "request.id"). An error attribute
+// with the "err" or "error" key is used as the entry error.
+type SlogHandler struct {
+	logger *Logger
+	fields *fields.Fields
+	err    error
+	prefix string
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler creates a slog.Handler that writes the records with the provided logger, or with the default one if
+// it is nil. To send all the slog records through golog, set it as the slog default:
+//
+//	slog.SetDefault(slog.New(golog.NewSlogHandler(logger)))
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	if logger == nil {
+		logger = Default()
+	}
+
+	return &SlogHandler{
+		logger: logger,
+		fields: fields.New(),
+	}
+}
+
+// Enabled reports whether the logger adapter writes records with the given level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.enabled(fromSlogLevel(level))
+}
+
+// Handle writes the record with the logger, keeping the record time if it is set. Context fields of the execution stored on ctx
+// are added to the record fields.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	level := fromSlogLevel(record.Level)
+
+	if !h.logger.sample(level, record.Message) {
+		return nil
+	}
+
+	logFields := fields.New()
+	if ctx != nil {
+		logFields.Merge(contextfields.Fields(ctx, ExecutionIDField))
+	}
+
+	logFields.Merge(h.fields)
+
+	err := h.err
+
+	record.Attrs(func(attr slog.Attr) bool {
+		if attrErr := addAttr(logFields, h.prefix, attr); attrErr != nil && err == nil {
+			err = attrErr
+		}
+
+		return true
+	})
+
+	logger := h.logger
+	if err != nil {
+		logger = logger.Err(err)
+	}
+
+	t := record.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	var pc uintptr
+	if logger.caller {
+		pc = record.PC
+	}
+
+	logger.log(&Entry{Time: t, Level: level, Message: record.Message}, logFields, pc)
+
+	return nil
+}
+
+// WithAttrs returns a new handler that adds the provided attributes to every record.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	child := h.clone()
+
+	for _, attr := range attrs {
+		if attrErr := addAttr(child.fields, child.prefix, attr); attrErr != nil && child.err == nil {
+			child.err = attrErr
+		}
+	}
+
+	return child
+}
+
+// WithGroup returns a new handler that adds the attributes of the following records and WithAttrs calls under the
+// provided group.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	child := h.clone()
+	child.prefix += name + "."
+
+	return child
+}
+
+func (h *SlogHandler) clone() *SlogHandler {
+	return &SlogHandler{
+		logger: h.logger,
+		fields: h.fields.Copy(),
+		err:    h.err,
+		prefix: h.prefix,
+	}
+}
+
+// addAttr sets the attribute on the fields under the provided prefix, flattening groups. It returns the attribute
+// value if it is an error that should be used as the entry error.
+func addAttr(logFields *fields.Fields, prefix string, attr slog.Attr) error {
+	if attrErr, ok := attr.Value.Any().(error); ok && prefix == "" && (attr.Key == "err" || attr.Key == "error") {
+		return attrErr
+	}
+
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return nil
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix += attr.Key + "."
+		}
+
+		var err error
+
+		for _, groupAttr := range attr.Value.Group() {
+			if attrErr := addAttr(logFields, groupPrefix, groupAttr); attrErr != nil && err == nil {
+				err = attrErr
+			}
+		}
+
+		return err
+	}
+
+	logFields.Set(prefix+attr.Key, attr.Value.Any())
+
+	return nil
+}
+
+// fromSlogLevel maps the slog level to the closest golog level. Levels above Error are mapped to Error, so slog
+// records never exit or panic.
+func fromSlogLevel(level slog.Level) levels.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return levels.TraceLevel
+	case level < slog.LevelInfo:
+		return levels.Debug
+	case level < slog.LevelWarn:
+		return levels.Info
+	case level < slog.LevelError:
+		return levels.Warn
+	default:
+		return levels.Error
+	}
+}
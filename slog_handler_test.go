@@ -0,0 +1,153 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	stdslog "log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/levels"
+)
+
+func TestSlogHandler(t *testing.T) {
+	decode := func(t *testing.T, output *bytes.Buffer) map[string]any {
+		t.Helper()
+
+		res := map[string]any{}
+
+		if errUnmarshal := json.Unmarshal(output.Bytes(), &res); errUnmarshal != nil {
+			t.Fatal(errUnmarshal)
+		}
+
+		return res
+	}
+
+	t.Run("should write records through the logger", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(
+			WithAdapter(slog.New(slog.WithWriter(&logOutput))),
+			WithHooks(func(entry *Entry) bool {
+				entry.Fields.Set("hooked", true)
+				return true
+			}),
+		).With(map[string]any{"service": "api"})
+
+		stdslog.New(NewSlogHandler(logger)).Warn("some message", "key1", "value1", "key2", 42)
+
+		res := decode(t, &logOutput)
+		assert.Equal(t, "warn", res["level"])
+		assert.Equal(t, "some message", res["message"])
+		assert.Equal(t, "api", res["service"])
+		assert.Equal(t, "value1", res["key1"])
+		assert.Equal(t, float64(42), res["key2"])
+		assert.Equal(t, true, res["hooked"])
+	})
+
+	t.Run("should flatten attrs and groups", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+		stdslog.New(NewSlogHandler(logger)).
+			With("key1", "value1").
+			WithGroup("request").
+			With("id", "some-id").
+			Info("some message", stdslog.Group("user", "name", "some-user"), "status", 200)
+
+		res := decode(t, &logOutput)
+		assert.Equal(t, "value1", res["key1"])
+		assert.Equal(t, "some-id", res["request.id"])
+		assert.Equal(t, "some-user", res["request.user.name"])
+		assert.Equal(t, float64(200), res["request.status"])
+	})
+
+	t.Run("should use error attr as entry error", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+		stdslog.New(NewSlogHandler(logger)).Error("some message", "err", errors.New("some error"))
+
+		res := decode(t, &logOutput)
+		assert.Equal(t, "error", res["level"])
+		assert.Equal(t, "some error", res["error"])
+		assert.NotNil(t, res["stack"])
+		assert.Nil(t, res["err"])
+	})
+
+	t.Run("should use error attr with log value as entry error", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+		stdslog.New(NewSlogHandler(logger)).Error("some message", "err", valuerError{code: "E42"})
+
+		res := decode(t, &logOutput)
+		assert.Equal(t, "valuer error", res["error"])
+		assert.Equal(t, "E42", res["error.code"])
+		assert.Nil(t, res["err.code"])
+	})
+
+	t.Run("should merge context fields of the record context", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+		ctx := NewExecution(context.Background(), "some-exec-id")
+		ctxLogger := logger.SetContext(ctx).SetContextFields(map[string]any{"ctx_key": "some context val"})
+		defer ctxLogger.FlushContextFields()
+
+		stdslog.New(NewSlogHandler(logger)).InfoContext(ctx, "some message")
+
+		res := decode(t, &logOutput)
+		assert.Equal(t, "some-exec-id", res["execution_id"])
+		assert.Equal(t, "some context val", res["ctx_key"])
+	})
+
+	t.Run("should keep record time", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+		recordTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		err := NewSlogHandler(logger).Handle(context.Background(), stdslog.NewRecord(recordTime, stdslog.LevelInfo, "some message", 0))
+		assert.NoError(t, err)
+
+		res := decode(t, &logOutput)
+		assert.Equal(t, "2024-01-02T03:04:05Z", res["time"])
+	})
+
+	t.Run("should report enabled levels from the adapter", func(t *testing.T) {
+		handler := NewSlogHandler(New(WithAdapter(slog.New(slog.WithLevel(levels.Warn)))))
+
+		assert.False(t, handler.Enabled(context.Background(), stdslog.LevelInfo))
+		assert.True(t, handler.Enabled(context.Background(), stdslog.LevelWarn))
+		assert.True(t, handler.Enabled(context.Background(), stdslog.LevelError+4))
+	})
+}
+
+func TestFromSlogLevel(t *testing.T) {
+	tests := []struct {
+		level    stdslog.Level
+		expected levels.Level
+	}{
+		{level: stdslog.LevelDebug - 4, expected: levels.TraceLevel},
+		{level: stdslog.LevelDebug, expected: levels.Debug},
+		{level: stdslog.LevelInfo, expected: levels.Info},
+		{level: stdslog.LevelInfo + 2, expected: levels.Info},
+		{level: stdslog.LevelWarn, expected: levels.Warn},
+		{level: stdslog.LevelError, expected: levels.Error},
+		{level: stdslog.LevelError + 8, expected: levels.Error},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, fromSlogLevel(test.level), test.level.String())
+	}
+}
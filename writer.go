@@ -0,0 +1,220 @@
+package golog
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danteay/golog/fields"
+	"github.com/danteay/golog/levels"
+)
+
+// WriterSourceField is the field name used to print the source configured with WithSource on the lines written by a
+// LineWriter. It differs from SourceField, so the caller location and the source of the lines can be logged together.
+var WriterSourceField = "writer"
+
+// maxLineSize is the maximum size of a line kept by a LineWriter while waiting for its line break. Longer lines are
+// logged in chunks of this size.
+const maxLineSize = 64 * 1024
+
+// levelPrefixes are the level prefixes detected by a LineWriter, e.g. "[ERROR]" or "ERROR:". Fatal and panic lines are
+// logged with Error level, so writing them never exits or panics.
+var levelPrefixes = map[string]levels.Level{
+	"TRACE":   levels.TraceLevel,
+	"DEBUG":   levels.Debug,
+	"INFO":    levels.Info,
+	"WARN":    levels.Warn,
+	"WARNING": levels.Warn,
+	"ERROR":   levels.Error,
+	"ERR":     levels.Error,
+	"FATAL":   levels.Error,
+	"PANIC":   levels.Error,
+}
+
+type writerOptions struct {
+	level        levels.Level
+	detectLevels bool
+	source       string
+}
+
+type WriterOption func(*writerOptions)
+
+// WithWriterLevel sets the level of the lines written by a LineWriter. Defaults to Info.
+func WithWriterLevel(level levels.Level) WriterOption {
+	return func(opts *writerOptions) {
+		opts.level = level
+	}
+}
+
+// WithLevelDetection makes a LineWriter detect the level of each line from its prefix, e.g. "[ERROR] message" or
+// "WARN: message". The prefix is removed from the message, and lines without a known prefix use the writer level.
+func WithLevelDetection() WriterOption {
+	return func(opts *writerOptions) {
+		opts.detectLevels = true
+	}
+}
+
+// WithSource adds the WriterSourceField field with the provided value to every line written by a LineWriter, e.g. the name of
+// the library or subprocess that writes them.
+func WithSource(source string) WriterOption {
+	return func(opts *writerOptions) {
+		opts.source = source
+	}
+}
+
+// LineWriter is an io.Writer that logs every written line as a log entry of the logger, so the output of the
+// standard library log package, or of a subprocess, goes through the logger hooks, fields and adapter.
+//
+// Partial lines are kept until their line break is written or the writer is closed. Empty lines are ignored.
+type LineWriter struct {
+	logger *Logger
+	opts   writerOptions
+	mutex  *sync.Mutex
+	buffer []byte
+}
+
+// NewLineWriter creates a LineWriter that logs the written lines with the provided logger, or with the default one
+// if it is nil.
+func NewLineWriter(logger *Logger, opts ...WriterOption) *LineWriter {
+	if logger == nil {
+		logger = Default()
+	}
+
+	writerOpts := writerOptions{
+		level: levels.Info,
+	}
+
+	for _, opt := range opts {
+		opt(&writerOpts)
+	}
+
+	return &LineWriter{
+		logger: logger,
+		opts:   writerOpts,
+		mutex:  &sync.Mutex{},
+	}
+}
+
+// Write logs every complete line of p and keeps the remaining partial line. It always consumes all of p.
+func (w *LineWriter) Write(p []byte) (n int, err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.buffer = append(w.buffer, p...)
+
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.logLine(w.buffer[:i])
+		w.buffer = w.buffer[i+1:]
+	}
+
+	for len(w.buffer) >= maxLineSize {
+		w.logLine(w.buffer[:maxLineSize])
+		w.buffer = w.buffer[maxLineSize:]
+	}
+
+	if len(w.buffer) == 0 {
+		w.buffer = nil
+	}
+
+	return len(p), nil
+}
+
+// Close logs the remaining partial line, if any.
+func (w *LineWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.buffer) > 0 {
+		w.logLine(w.buffer)
+		w.buffer = nil
+	}
+
+	return nil
+}
+
+func (w *LineWriter) logLine(line []byte) {
+	msg := strings.TrimRight(string(line), "\r\n\t ")
+	if msg == "" {
+		return
+	}
+
+	level := w.opts.level
+
+	if w.opts.detectLevels {
+		if detected, rest, ok := detectLevel(msg); ok {
+			level, msg = detected, rest
+		}
+	}
+
+	if !w.logger.enabled(level) || !w.logger.sample(level, msg) {
+		return
+	}
+
+	var extra *fields.Fields
+	if w.opts.source != "" {
+		extra = fields.New().Set(WriterSourceField, w.opts.source)
+	}
+
+	w.logger.log(&Entry{Time: time.Now(), Level: level, Message: msg}, extra, 0)
+}
+
+// detectLevel returns the level of the line prefix and the line without it. Prefixes are matched case-insensitively
+// in the "[LEVEL] message" and "LEVEL: message" forms.
+func detectLevel(line string) (levels.Level, string, bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+
+	var name, rest string
+
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		end := strings.IndexByte(trimmed, ']')
+		if end < 0 {
+			return 0, line, false
+		}
+
+		name, rest = trimmed[1:end], trimmed[end+1:]
+	default:
+		end := strings.IndexByte(trimmed, ':')
+		if end < 0 {
+			return 0, line, false
+		}
+
+		name, rest = trimmed[:end], trimmed[end+1:]
+	}
+
+	level, ok := levelPrefixes[strings.ToUpper(strings.TrimSpace(name))]
+	if !ok {
+		return 0, line, false
+	}
+
+	return level, strings.TrimLeft(rest, " \t"), true
+}
+
+// NewStdLogger creates a standard library logger that logs every line with the provided logger. The returned logger
+// has no prefix nor flags, as the time is already added by the adapter.
+func NewStdLogger(logger *Logger, opts ...WriterOption) *log.Logger {
+	return log.New(NewLineWriter(logger, opts...), "", 0)
+}
+
+// RedirectStdLog makes the standard library log package write through the provided logger, removing its prefix and
+// flags. It returns a function that restores the previous output, prefix and flags.
+func RedirectStdLog(logger *Logger, opts ...WriterOption) func() {
+	prevWriter, prevPrefix, prevFlags := log.Writer(), log.Prefix(), log.Flags()
+
+	log.SetOutput(NewLineWriter(logger, opts...))
+	log.SetPrefix("")
+	log.SetFlags(0)
+
+	return func() {
+		log.SetOutput(prevWriter)
+		log.SetPrefix(prevPrefix)
+		log.SetFlags(prevFlags)
+	}
+}
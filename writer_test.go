@@ -0,0 +1,146 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/danteay/golog/adapters/slog"
+	"github.com/danteay/golog/levels"
+)
+
+func TestLineWriter(t *testing.T) {
+	decode := func(t *testing.T, output *bytes.Buffer) []map[string]any {
+		t.Helper()
+
+		var msgs []map[string]any
+
+		for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+			if line == "" {
+				continue
+			}
+
+			res := map[string]any{}
+
+			if errUnmarshal := json.Unmarshal([]byte(line), &res); errUnmarshal != nil {
+				t.Fatal(errUnmarshal)
+			}
+
+			msgs = append(msgs, res)
+		}
+
+		return msgs
+	}
+
+	t.Run("should log every line", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+		writer := NewLineWriter(logger, WithSource("worker"))
+
+		_, _ = writer.Write([]byte("first line\nsecond "))
+		_, _ = writer.Write([]byte("line\r\n\nthird"))
+
+		msgs := decode(t, &logOutput)
+		assert.Len(t, msgs, 2)
+		assert.Equal(t, "first line", msgs[0]["message"])
+		assert.Equal(t, "second line", msgs[1]["message"])
+		assert.Equal(t, "info", msgs[1]["level"])
+		assert.Equal(t, "worker", msgs[1]["writer"])
+
+		logOutput.Reset()
+
+		assert.NoError(t, writer.Close())
+
+		msgs = decode(t, &logOutput)
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, "third", msgs[0]["message"])
+	})
+
+	t.Run("should detect level prefixes", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput), slog.WithLevel(levels.Debug))))
+		writer := NewLineWriter(logger, WithWriterLevel(levels.Debug), WithLevelDetection())
+
+		_, _ = writer.Write([]byte("[ERROR] some error\nWARN: some warning\n  [info]   some info\nFATAL: some fatal\nsome line\n"))
+
+		msgs := decode(t, &logOutput)
+		assert.Len(t, msgs, 5)
+		assert.Equal(t, "error", msgs[0]["level"])
+		assert.Equal(t, "some error", msgs[0]["message"])
+		assert.Equal(t, "warn", msgs[1]["level"])
+		assert.Equal(t, "some warning", msgs[1]["message"])
+		assert.Equal(t, "info", msgs[2]["level"])
+		assert.Equal(t, "some info", msgs[2]["message"])
+		assert.Equal(t, "error", msgs[3]["level"])
+		assert.Equal(t, "debug", msgs[4]["level"])
+		assert.Equal(t, "some line", msgs[4]["message"])
+	})
+
+	t.Run("should keep unknown prefixes", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+		writer := NewLineWriter(logger, WithLevelDetection())
+
+		_, _ = writer.Write([]byte("[main] started\nkey: value\n"))
+
+		msgs := decode(t, &logOutput)
+		assert.Len(t, msgs, 2)
+		assert.Equal(t, "[main] started", msgs[0]["message"])
+		assert.Equal(t, "key: value", msgs[1]["message"])
+	})
+
+	t.Run("should split long lines", func(t *testing.T) {
+		var logOutput bytes.Buffer
+
+		logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+		writer := NewLineWriter(logger)
+
+		_, _ = writer.Write([]byte(strings.Repeat("a", maxLineSize+10)))
+
+		msgs := decode(t, &logOutput)
+		assert.Len(t, msgs, 1)
+		assert.Len(t, msgs[0]["message"], maxLineSize)
+	})
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	logger := New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))
+
+	restore := RedirectStdLog(logger, WithLevelDetection(), WithSource("stdlog"))
+	log.Printf("[WARN] some %s", "warning")
+	restore()
+
+	res := map[string]any{}
+
+	if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+		t.Fatal(errUnmarshal)
+	}
+
+	assert.Equal(t, "warn", res["level"])
+	assert.Equal(t, "some warning", res["message"])
+	assert.Equal(t, "stdlog", res["writer"])
+	assert.NotEqual(t, 0, log.Flags())
+}
+
+func TestNewStdLogger(t *testing.T) {
+	var logOutput bytes.Buffer
+
+	NewStdLogger(New(WithAdapter(slog.New(slog.WithWriter(&logOutput))))).Println("some message")
+
+	res := map[string]any{}
+
+	if errUnmarshal := json.Unmarshal(logOutput.Bytes(), &res); errUnmarshal != nil {
+		t.Fatal(errUnmarshal)
+	}
+
+	assert.Equal(t, "some message", res["message"])
+}